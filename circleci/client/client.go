@@ -5,9 +5,14 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"path"
+	"strings"
+	"time"
 
 	"github.com/CircleCI-Public/circleci-cli/api"
 	"github.com/CircleCI-Public/circleci-cli/settings"
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/logging"
 
 	"github.com/mrolla/terraform-provider-circleci/circleci/client/rest"
 )
@@ -17,8 +22,19 @@ import (
 type Client struct {
 	contexts     *api.ContextRestClient
 	rest         *rest.Client
+	restV1       *rest.Client
 	vcs          string
 	organization string
+
+	// server is set for CircleCI server installations, whose capabilities are detected
+	server       bool
+	host         string
+	capabilities capabilities
+
+	organizationIDs organizationIDs
+
+	// valueHashKey keys the hashes of the values of environment variables
+	valueHashKey string
 }
 
 // Config configures a Client
@@ -26,38 +42,125 @@ type Config struct {
 	URL   string
 	Token string
 
+	// Host selects a CircleCI server installation, instead of URL. The endpoints are relative to it.
+	Host            string
+	RESTEndpoint    string
+	GraphQLEndpoint string
+
 	VCS          string
 	Organization string
+
+	// MaxRetries is the number of times a rate limited or failed request is retried
+	MaxRetries int
+	// RequestTimeout limits each attempt of a request
+	RequestTimeout time.Duration
+	// RateLimiter throttles all the requests when set
+	RateLimiter *rest.RateLimiter
+
+	// CACertFile holds PEM certificate authorities trusted in addition to the system ones
+	CACertFile         string
+	InsecureSkipVerify bool
+	ClientCertFile     string
+	ClientKeyFile      string
+	// ProxyURL overrides the proxy from the HTTP_PROXY and HTTPS_PROXY environment variables
+	ProxyURL string
+
+	// ValueHashKey makes HashValue return HMACs of the values of environment variables
+	ValueHashKey string
 }
 
 // New initializes a client object for the provider
 func New(config Config) (*Client, error) {
-	u, err := url.Parse(config.URL)
+	rootURL, restPath, err := apiURL(config)
 	if err != nil {
 		return nil, err
 	}
 
-	rootURL := fmt.Sprintf("%s://%s", u.Scheme, u.Host)
+	// Some endpoints are only available in API v1.1, which lives next to the configured v2 path
+	v1Path := path.Join(path.Dir(strings.TrimSuffix(restPath, "/")), "v1.1")
+
+	var transport http.RoundTripper
+	transport, err = newTransport(config)
+	if err != nil {
+		return nil, err
+	}
+
+	// Each attempt of a request is logged at TF_LOG=DEBUG, without secrets
+	if logging.IsDebugOrHigher() {
+		transport = &rest.LoggingTransport{Transport: transport}
+	}
+
+	// Every attempt of a request, including retries, waits for the rate limiter
+	if config.RateLimiter != nil {
+		transport = &rest.RateLimitTransport{Transport: transport, Limiter: config.RateLimiter}
+	}
+
+	// All requests, including the ones of the upstream client, are retried the same way
+	httpClient := &http.Client{
+		Transport: &rest.RetryTransport{
+			Transport:  transport,
+			MaxRetries: config.MaxRetries,
+			Timeout:    config.RequestTimeout,
+		},
+	}
 
+	// The upstream client drops the status of failed requests, so its errors are turned into HTTP errors
 	contexts, err := api.NewContextRestClient(settings.Config{
 		Host:         rootURL,
-		RestEndpoint: u.Path,
+		RestEndpoint: restPath,
+		Endpoint:     config.GraphQLEndpoint,
 		Token:        config.Token,
-		HTTPClient:   http.DefaultClient,
+		HTTPClient:   &http.Client{Transport: &rest.ErrorTransport{Transport: httpClient.Transport}},
 	})
 	if err != nil {
 		return nil, err
 	}
 
 	return &Client{
-		rest:     rest.New(rootURL, u.Path, config.Token),
+		rest:     rest.New(rootURL, restPath, config.Token, httpClient),
+		restV1:   rest.New(rootURL, v1Path, config.Token, httpClient),
 		contexts: contexts,
 
 		vcs:          config.VCS,
 		organization: config.Organization,
+
+		server:       config.Host != "",
+		host:         rootURL,
+		capabilities: capabilities{supported: map[Capability]bool{}},
+
+		valueHashKey: config.ValueHashKey,
 	}, nil
 }
 
+// apiURL returns the root URL of the API and the path of its v2 endpoint, either from the URL of
+// CircleCI cloud or from the host and endpoint of a CircleCI server installation
+func apiURL(config Config) (string, string, error) {
+	if config.Host == "" {
+		u, err := url.Parse(config.URL)
+		if err != nil {
+			return "", "", err
+		}
+
+		return fmt.Sprintf("%s://%s", u.Scheme, u.Host), u.Path, nil
+	}
+
+	u, err := url.Parse(config.Host)
+	if err != nil {
+		return "", "", err
+	}
+
+	if u.Scheme == "" || u.Host == "" {
+		return "", "", fmt.Errorf("invalid host %q, expected a URL such as https://circleci.example.com", config.Host)
+	}
+
+	restPath := config.RESTEndpoint
+	if restPath == "" {
+		restPath = "api/v2"
+	}
+
+	return fmt.Sprintf("%s://%s", u.Scheme, u.Host), "/" + strings.Trim(restPath, "/") + "/", nil
+}
+
 // Organization returns the organization for a request. If an organization is provided,
 // that is returned. Next, an organization configured in the provider is returned.
 // If neither are set, an error is returned.
@@ -73,21 +176,76 @@ func (c *Client) Organization(org string) (string, error) {
 	return "", errors.New("organization is required")
 }
 
-// Slug returns a project slug, including the VCS, organization, and project names
+// VCSTypes are the accepted VCS types. Organizations integrated through GitLab or the GitHub App
+// have the circleci type, and are identified by their ID (UUID) instead of a name.
+var VCSTypes = []string{"github", "bitbucket", "gitlab", "circleci"}
+
+// vcsSlug returns the VCS part of the slugs of the organization and its projects
+func (c *Client) vcsSlug(org string) string {
+	if c.vcs == "gitlab" || c.vcs == "circleci" || isUUID(org) {
+		return "circleci"
+	}
+
+	return c.vcs
+}
+
+// Slug returns a project slug, including the VCS, organization, and project names. The organization
+// and project can also be IDs, and the project can be a full slug, which is returned unchanged.
 func (c *Client) Slug(org, project string) (string, error) {
+	if IsProjectSlug(project) {
+		return project, nil
+	}
+
 	o, err := c.Organization(org)
 	if err != nil {
 		return "", err
 	}
 
-	return fmt.Sprintf("%s/%s/%s", c.vcs, o, project), nil
+	return fmt.Sprintf("%s/%s/%s", c.vcsSlug(o), o, project), nil
 }
 
-func isNotFound(err error) bool {
+// ProjectOrganization returns the organization of a project, which is part of the project
+// when it is a full slug
+func (c *Client) ProjectOrganization(org, project string) (string, error) {
+	if IsProjectSlug(project) {
+		return strings.Split(project, "/")[1], nil
+	}
+
+	return c.Organization(org)
+}
+
+// IsProjectSlug returns whether a project is a full slug such as circleci/<org-id>/<project-id>,
+// instead of a name or an ID
+func IsProjectSlug(project string) bool {
+	parts := strings.Split(project, "/")
+	if len(parts) != 3 {
+		return false
+	}
+
+	for _, part := range parts {
+		if part == "" {
+			return false
+		}
+	}
+
+	return true
+}
+
+func isUUID(s string) bool {
+	_, err := uuid.Parse(s)
+	return err == nil
+}
+
+// upstreamError returns the HTTP error of a failed request of the upstream client, which is wrapped in a *url.Error
+func upstreamError(err error) error {
 	var httpError *rest.HTTPError
-	if errors.As(err, &httpError) && httpError.Code == 404 {
-		return true
+	if errors.As(err, &httpError) {
+		return httpError
 	}
 
-	return false
+	return err
+}
+
+func isNotFound(err error) bool {
+	return errors.Is(err, rest.ErrNotFound)
 }
@@ -0,0 +1,89 @@
+package client
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mrolla/terraform-provider-circleci/circleci/client/rest"
+)
+
+func TestSlug(t *testing.T) {
+	const (
+		orgID     = "8f2ae5c5-4a37-4d0e-a1f6-8c1a3f0e5b51"
+		projectID = "2b6a2bd4-0cbb-4b0e-9d3e-4fd4c1a3e0c7"
+	)
+
+	cases := []struct {
+		name    string
+		vcs     string
+		org     string
+		project string
+		slug    string
+	}{
+		{"github", "github", "org", "project", "github/org/project"},
+		{"bitbucket", "bitbucket", "org", "project", "bitbucket/org/project"},
+		{"gitlab", "gitlab", orgID, projectID, "circleci/" + orgID + "/" + projectID},
+		{"github app", "circleci", orgID, projectID, "circleci/" + orgID + "/" + projectID},
+		{"organization ID", "github", orgID, projectID, "circleci/" + orgID + "/" + projectID},
+		{"project slug", "github", "", "circleci/" + orgID + "/" + projectID, "circleci/" + orgID + "/" + projectID},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := &Client{vcs: tc.vcs}
+
+			slug, err := c.Slug(tc.org, tc.project)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.slug, slug)
+		})
+	}
+
+	c := &Client{vcs: "github"}
+	org, err := c.ProjectOrganization("", "circleci/"+orgID+"/"+projectID)
+	assert.NoError(t, err)
+	assert.Equal(t, orgID, org)
+
+	_, err = c.Slug("", "project")
+	assert.EqualError(t, err, "organization is required")
+}
+
+func TestHashValue(t *testing.T) {
+	c := &Client{}
+
+	// echo -n secret | openssl dgst -sha256 -binary | base64
+	assert.Equal(t, "K7gNU3sdo+OL0wNhqoVWhr3g6s1xYv72ol/pe/Unols=", c.HashValue("secret"))
+
+	keyed := &Client{valueHashKey: "key"}
+
+	// echo -n secret | openssl dgst -sha256 -hmac key -binary | base64
+	assert.Equal(t, "hmac-sha256:Jc88RMjzkxPoy/fCPiL+iy7osojuUgawpjl1g6H38O8=", keyed.HashValue("secret"))
+	assert.NotEqual(t, keyed.HashValue("secret"), keyed.HashValue("other"))
+	assert.NotEqual(t, c.HashValue("secret"), keyed.HashValue("secret"))
+}
+
+func TestUpstreamClientHTTPError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "1234")
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"message":"Permission denied"}`))
+	}))
+	defer server.Close()
+
+	c, err := New(Config{URL: server.URL + "/api/v2/", Token: "token", VCS: "github"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Requests of the upstream client return the same HTTP errors as the other requests
+	for _, err := range []error{
+		c.DeleteContext("6d87b798-5edb-4d99-b424-ce73b43affb9"),
+		c.CreateOrUpdateContextEnvironmentVariable("6d87b798-5edb-4d99-b424-ce73b43affb9", "NAME", "value"),
+		c.DeleteContextEnvironmentVariable("6d87b798-5edb-4d99-b424-ce73b43affb9", "NAME"),
+	} {
+		assert.ErrorIs(t, err, rest.ErrForbidden)
+		assert.EqualError(t, err, "Permission denied (request ID 1234)")
+	}
+}
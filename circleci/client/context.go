@@ -6,7 +6,6 @@ import (
 	"net/url"
 
 	"github.com/CircleCI-Public/circleci-cli/api"
-	"github.com/google/uuid"
 )
 
 var ErrContextNotFound = errors.New("context not found")
@@ -39,16 +38,82 @@ func (c *Client) GetContextByName(name, org string) (*api.Context, error) {
 		return nil, err
 	}
 
-	return c.contexts.ContextByName(c.vcs, o, name)
+	contexts, err := c.listContexts(o)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, ctx := range contexts {
+		if ctx.Name == name {
+			return &ctx, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %s", ErrContextNotFound, name)
+}
+
+// ListContexts lists all contexts of an organization
+func (c *Client) ListContexts(org string) (*[]api.Context, error) {
+	o, err := c.Organization(org)
+	if err != nil {
+		return nil, err
+	}
+
+	contexts, err := c.listContexts(o)
+	if err != nil {
+		return nil, err
+	}
+
+	return &contexts, nil
 }
 
 // GetContextByIDOrName gets a context by ID if a UUID is specified, and by name otherwise
 func (c *Client) GetContextByIDOrName(org, id string) (*api.Context, error) {
-	if _, uuidErr := uuid.Parse(id); uuidErr == nil {
+	if isUUID(id) {
 		return c.GetContext(id)
 	} else {
-		return c.contexts.ContextByName(c.vcs, org, id)
+		return c.GetContextByName(id, org)
+	}
+}
+
+type listContextsResponse struct {
+	Items         []api.Context `json:"items"`
+	NextPageToken string        `json:"next_page_token"`
+}
+
+// listContexts lists the contexts of an organization, identified by its ID or its name
+func (c *Client) listContexts(org string) ([]api.Context, error) {
+	var contexts []api.Context
+
+	params := url.Values{}
+	if isUUID(org) {
+		params.Set("owner-id", org)
+	} else {
+		params.Set("owner-slug", fmt.Sprintf("%s/%s", c.vcsSlug(org), org))
 	}
+
+	for {
+		req, err := c.rest.NewRequest("GET", &url.URL{Path: "context", RawQuery: params.Encode()}, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		resp := &listContextsResponse{}
+		_, err = c.rest.DoRequest(req, resp)
+		if err != nil {
+			return nil, err
+		}
+
+		contexts = append(contexts, resp.Items...)
+
+		if resp.NextPageToken == "" {
+			break
+		}
+
+		params.Set("page-token", resp.NextPageToken)
+	}
+
+	return contexts, nil
 }
 
 type createContextRequest struct {
@@ -57,7 +122,8 @@ type createContextRequest struct {
 }
 
 type contextOwner struct {
-	Slug string `json:"slug"`
+	ID   string `json:"id,omitempty"`
+	Slug string `json:"slug,omitempty"`
 	Type string `json:"type"`
 }
 
@@ -68,12 +134,16 @@ func (c *Client) CreateContext(org, name string) (*api.Context, error) {
 		return nil, err
 	}
 
+	owner := &contextOwner{Type: "organization"}
+	if isUUID(org) {
+		owner.ID = org
+	} else {
+		owner.Slug = fmt.Sprintf("%s/%s", c.vcsSlug(org), org)
+	}
+
 	req, err := c.rest.NewRequest("POST", &url.URL{Path: "context"}, &createContextRequest{
-		Name: name,
-		Owner: &contextOwner{
-			Slug: fmt.Sprintf("%s/%s", c.vcs, org),
-			Type: "organization",
-		},
+		Name:  name,
+		Owner: owner,
 	})
 	if err != nil {
 		return nil, err
@@ -88,6 +158,7 @@ func (c *Client) CreateContext(org, name string) (*api.Context, error) {
 	return ctx, nil
 }
 
+// DeleteContext deletes a context by its ID (UUID)
 func (c *Client) DeleteContext(id string) error {
-	return c.contexts.DeleteContext(id)
+	return upstreamError(c.contexts.DeleteContext(id))
 }
@@ -1,16 +1,86 @@
 package client
 
-import "github.com/CircleCI-Public/circleci-cli/api"
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// ContextEnvironmentVariable is an environment variable of a context. Its value is never returned by the API.
+type ContextEnvironmentVariable struct {
+	Variable  string    `json:"variable"`
+	ContextID string    `json:"context_id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type listContextEnvironmentVariablesResponse struct {
+	Items         []ContextEnvironmentVariable `json:"items"`
+	NextPageToken string                       `json:"next_page_token"`
+}
 
 // CreateOrUpdateContextEnvironmentVariable creates a new context environment variable
 func (c *Client) CreateOrUpdateContextEnvironmentVariable(ctx, variable, value string) error {
 	// CreateEnvironmentVariable calls PUT and can be used to update an existing variable with a matching context/name
-	return c.contexts.CreateEnvironmentVariable(ctx, variable, value)
+	return upstreamError(c.contexts.CreateEnvironmentVariable(ctx, variable, value))
 }
 
 // ListContextEnvironmentVariables lists all environment variables for a given context
-func (c *Client) ListContextEnvironmentVariables(ctx string) (*[]api.EnvironmentVariable, error) {
-	return c.contexts.EnvironmentVariables(ctx)
+func (c *Client) ListContextEnvironmentVariables(ctx string) (*[]ContextEnvironmentVariable, error) {
+	// The upstream client does not decode the timestamps of the variables, so they are listed here
+	envs := []ContextEnvironmentVariable{}
+
+	params := url.Values{}
+
+	for {
+		req, err := c.rest.NewRequest("GET", &url.URL{Path: fmt.Sprintf("context/%s/environment-variable", ctx), RawQuery: params.Encode()}, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		resp := &listContextEnvironmentVariablesResponse{}
+		_, err = c.rest.DoRequest(req, resp)
+		if err != nil {
+			if isNotFound(err) {
+				return nil, ErrContextNotFound
+			}
+
+			return nil, err
+		}
+
+		envs = append(envs, resp.Items...)
+
+		if resp.NextPageToken == "" {
+			break
+		}
+
+		params.Set("page-token", resp.NextPageToken)
+	}
+
+	return &envs, nil
+}
+
+var ErrContextEnvironmentVariableNotFound = errors.New("context environment variable not found")
+
+// GetContextEnvironmentVariable lists all environment variables for a given context and returns the specified variable
+func (c *Client) GetContextEnvironmentVariable(ctx, variable string) (*ContextEnvironmentVariable, error) {
+	envs, err := c.ListContextEnvironmentVariables(ctx)
+	if err != nil {
+		if errors.Is(err, ErrContextNotFound) {
+			return nil, ErrContextEnvironmentVariableNotFound
+		}
+
+		return nil, err
+	}
+
+	for _, env := range *envs {
+		if env.Variable == variable {
+			return &env, nil
+		}
+	}
+
+	return nil, ErrContextEnvironmentVariableNotFound
 }
 
 // HasContextEnvironmentVariable lists all environment variables for a given context and checks whether the specified variable is defined.
@@ -18,7 +88,7 @@ func (c *Client) ListContextEnvironmentVariables(ctx string) (*[]api.Environment
 func (c *Client) HasContextEnvironmentVariable(ctx, variable string) (bool, error) {
 	envs, err := c.ListContextEnvironmentVariables(ctx)
 	if err != nil {
-		if isNotFound(err) {
+		if errors.Is(err, ErrContextNotFound) {
 			return false, nil
 		}
 
@@ -36,5 +106,5 @@ func (c *Client) HasContextEnvironmentVariable(ctx, variable string) (bool, erro
 
 // DeleteContextEnvironmentVariable deletes a context environment variable by context ID and name
 func (c *Client) DeleteContextEnvironmentVariable(ctx, variable string) error {
-	return c.contexts.DeleteEnvironmentVariable(ctx, variable)
+	return upstreamError(c.contexts.DeleteEnvironmentVariable(ctx, variable))
 }
@@ -0,0 +1,116 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+)
+
+var ErrContextRestrictionNotFound = errors.New("context restriction not found")
+
+// ContextRestriction restricts which projects or users can use a context
+type ContextRestriction struct {
+	ID        string `json:"id,omitempty"`
+	ContextID string `json:"context_id,omitempty"`
+	ProjectID string `json:"project_id,omitempty"`
+	Name      string `json:"name,omitempty"`
+	Type      string `json:"restriction_type"`
+	Value     string `json:"restriction_value"`
+}
+
+type listContextRestrictionsResponse struct {
+	Items         []ContextRestriction `json:"items"`
+	NextPageToken string               `json:"next_page_token"`
+}
+
+// ListContextRestrictions lists all restrictions of a context
+func (c *Client) ListContextRestrictions(ctx string) ([]ContextRestriction, error) {
+	if err := c.require(CapabilityContextRestrictions); err != nil {
+		return nil, err
+	}
+
+	var restrictions []ContextRestriction
+
+	params := url.Values{}
+
+	for {
+		req, err := c.rest.NewRequest("GET", &url.URL{Path: fmt.Sprintf("context/%s/restrictions", ctx), RawQuery: params.Encode()}, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		resp := &listContextRestrictionsResponse{}
+		_, err = c.rest.DoRequest(req, resp)
+		if err != nil {
+			return nil, err
+		}
+
+		restrictions = append(restrictions, resp.Items...)
+
+		if resp.NextPageToken == "" {
+			break
+		}
+
+		params.Set("page-token", resp.NextPageToken)
+	}
+
+	return restrictions, nil
+}
+
+// GetContextRestriction gets an existing restriction of a context by its ID (UUID)
+func (c *Client) GetContextRestriction(ctx, id string) (*ContextRestriction, error) {
+	restrictions, err := c.ListContextRestrictions(ctx)
+	if err != nil {
+		if isNotFound(err) {
+			return nil, ErrContextRestrictionNotFound
+		}
+
+		return nil, err
+	}
+
+	for _, restriction := range restrictions {
+		if restriction.ID == id {
+			return &restriction, nil
+		}
+	}
+
+	return nil, ErrContextRestrictionNotFound
+}
+
+// CreateContextRestriction creates a new restriction on a context and returns the created restriction object
+func (c *Client) CreateContextRestriction(ctx, restrictionType, value string) (*ContextRestriction, error) {
+	if err := c.require(CapabilityContextRestrictions); err != nil {
+		return nil, err
+	}
+
+	req, err := c.rest.NewRequest("POST", &url.URL{Path: fmt.Sprintf("context/%s/restrictions", ctx)}, &ContextRestriction{
+		Type:  restrictionType,
+		Value: value,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	restriction := &ContextRestriction{}
+	_, err = c.rest.DoRequest(req, restriction)
+	if err != nil {
+		return nil, err
+	}
+
+	return restriction, nil
+}
+
+// DeleteContextRestriction deletes a restriction of a context by its ID (UUID)
+func (c *Client) DeleteContextRestriction(ctx, id string) error {
+	if err := c.require(CapabilityContextRestrictions); err != nil {
+		return err
+	}
+
+	req, err := c.rest.NewRequest("DELETE", &url.URL{Path: fmt.Sprintf("context/%s/restrictions/%s", ctx, id)}, nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.rest.DoRequest(req, nil)
+	return err
+}
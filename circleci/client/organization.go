@@ -0,0 +1,138 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"sync"
+)
+
+var ErrOrganizationNotFound = errors.New("organization not found")
+
+// Collaboration is an organization the token has access to
+type Collaboration struct {
+	ID        string `json:"id"`
+	VCSType   string `json:"vcs-type"`
+	Name      string `json:"name"`
+	Slug      string `json:"slug"`
+	AvatarURL string `json:"avatar_url"`
+}
+
+// ListCollaborations lists the organizations the token has access to
+func (c *Client) ListCollaborations() ([]Collaboration, error) {
+	req, err := c.rest.NewRequest("GET", &url.URL{Path: "me/collaborations"}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var collaborations []Collaboration
+	_, err = c.rest.DoRequest(req, &collaborations)
+	if err != nil {
+		return nil, err
+	}
+
+	return collaborations, nil
+}
+
+// User is the user owning the token
+type User struct {
+	ID    string `json:"id"`
+	Login string `json:"login"`
+	Name  string `json:"name"`
+}
+
+// GetCurrentUser gets the user owning the token
+func (c *Client) GetCurrentUser() (*User, error) {
+	req, err := c.rest.NewRequest("GET", &url.URL{Path: "me"}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	user := &User{}
+	_, err = c.rest.DoRequest(req, user)
+	if err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// GetOrganization gets an organization the token has access to by its ID, its slug (e.g. gh/org or
+// github/org) or its name
+func (c *Client) GetOrganization(org string) (*Collaboration, error) {
+	o, err := c.Organization(org)
+	if err != nil {
+		return nil, err
+	}
+
+	collaborations, err := c.ListCollaborations()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, collaboration := range collaborations {
+		if c.matchesOrganization(collaboration, o) {
+			c.cacheOrganizationID(o, collaboration.ID)
+			return &collaboration, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %s", ErrOrganizationNotFound, o)
+}
+
+// matchesOrganization returns whether an organization has an ID, slug or name. A name only matches
+// organizations of the configured VCS type.
+func (c *Client) matchesOrganization(collaboration Collaboration, org string) bool {
+	switch org {
+	case collaboration.ID, collaboration.Slug, fmt.Sprintf("%s/%s", collaboration.VCSType, collaboration.Name):
+		return true
+	case collaboration.Name:
+		return collaboration.VCSType == c.vcs || collaboration.VCSType == c.vcsSlug(org)
+	}
+
+	return false
+}
+
+// GetOrganizationID returns the ID (UUID) of an organization by its name or slug. An ID is returned
+// unchanged. IDs are cached, since resources may need them for every request.
+func (c *Client) GetOrganizationID(org string) (string, error) {
+	o, err := c.Organization(org)
+	if err != nil {
+		return "", err
+	}
+
+	if isUUID(o) {
+		return o, nil
+	}
+
+	c.organizationIDs.mu.Lock()
+	id, ok := c.organizationIDs.ids[o]
+	c.organizationIDs.mu.Unlock()
+	if ok {
+		return id, nil
+	}
+
+	collaboration, err := c.GetOrganization(o)
+	if err != nil {
+		return "", err
+	}
+
+	return collaboration.ID, nil
+}
+
+// organizationIDs caches the IDs of organizations by the names or slugs they were requested with
+type organizationIDs struct {
+	mu  sync.Mutex
+	ids map[string]string
+}
+
+func (c *Client) cacheOrganizationID(org, id string) {
+	c.organizationIDs.mu.Lock()
+	defer c.organizationIDs.mu.Unlock()
+
+	if c.organizationIDs.ids == nil {
+		c.organizationIDs.ids = map[string]string{}
+	}
+
+	c.organizationIDs.ids[org] = id
+}
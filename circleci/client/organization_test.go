@@ -0,0 +1,54 @@
+package client
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetOrganization(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		_, _ = w.Write([]byte(`[
+			{"id": "8f2ae5c5-4a37-4d0e-a1f6-8c1a3f0e5b51", "vcs-type": "github", "name": "org", "slug": "gh/org"},
+			{"id": "2b6a2bd4-0cbb-4b0e-9d3e-4fd4c1a3e0c7", "vcs-type": "bitbucket", "name": "org", "slug": "bb/org"},
+			{"id": "c1b7d0b6-3d6e-4f7a-9a3e-0d8f1f2b7e44", "vcs-type": "circleci", "name": "app-org", "slug": "circleci/c1b7d0b6-3d6e-4f7a-9a3e-0d8f1f2b7e44"}
+		]`))
+	}))
+	defer server.Close()
+
+	c, err := New(Config{URL: server.URL + "/api/v2/", Token: "token", VCS: "github", Organization: "org"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, org := range []string{"", "org", "gh/org", "github/org", "8f2ae5c5-4a37-4d0e-a1f6-8c1a3f0e5b51"} {
+		found, err := c.GetOrganization(org)
+		if assert.NoError(t, err, org) {
+			assert.Equal(t, "8f2ae5c5-4a37-4d0e-a1f6-8c1a3f0e5b51", found.ID, org)
+		}
+	}
+
+	found, err := c.GetOrganization("bb/org")
+	if assert.NoError(t, err) {
+		assert.Equal(t, "bitbucket", found.VCSType)
+	}
+
+	found, err = c.GetOrganization("circleci/c1b7d0b6-3d6e-4f7a-9a3e-0d8f1f2b7e44")
+	if assert.NoError(t, err) {
+		assert.Equal(t, "app-org", found.Name)
+	}
+
+	_, err = c.GetOrganization("other")
+	assert.ErrorIs(t, err, ErrOrganizationNotFound)
+
+	// The IDs of organizations which were already found are cached
+	requests = 0
+	id, err := c.GetOrganizationID("gh/org")
+	assert.NoError(t, err)
+	assert.Equal(t, "8f2ae5c5-4a37-4d0e-a1f6-8c1a3f0e5b51", id)
+	assert.Equal(t, 0, requests)
+}
@@ -4,8 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"net/url"
-
-	"github.com/mrolla/terraform-provider-circleci/circleci/client/rest"
+	"time"
 )
 
 type projectEnvironmentVariable struct {
@@ -13,6 +12,84 @@ type projectEnvironmentVariable struct {
 	Value string `json:"value"`
 }
 
+// ProjectEnvironmentVariable is an environment variable of a project. Its value is masked by the API.
+type ProjectEnvironmentVariable struct {
+	Name      string     `json:"name"`
+	Value     string     `json:"value"`
+	CreatedAt *time.Time `json:"created-at,omitempty"`
+}
+
+type listProjectEnvironmentVariablesResponse struct {
+	Items         []ProjectEnvironmentVariable `json:"items"`
+	NextPageToken string                       `json:"next_page_token"`
+}
+
+// ListProjectEnvironmentVariables lists all environment variables of a project
+func (c *Client) ListProjectEnvironmentVariables(org, project string) (*[]ProjectEnvironmentVariable, error) {
+	slug, err := c.Slug(org, project)
+	if err != nil {
+		return nil, err
+	}
+
+	envs := []ProjectEnvironmentVariable{}
+
+	params := url.Values{}
+
+	for {
+		req, err := c.rest.NewRequest("GET", &url.URL{Path: fmt.Sprintf("project/%s/envvar", slug), RawQuery: params.Encode()}, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		resp := &listProjectEnvironmentVariablesResponse{}
+		_, err = c.rest.DoRequest(req, resp)
+		if err != nil {
+			if isNotFound(err) {
+				return nil, ErrProjectNotFound
+			}
+
+			return nil, err
+		}
+
+		envs = append(envs, resp.Items...)
+
+		if resp.NextPageToken == "" {
+			break
+		}
+
+		params.Set("page-token", resp.NextPageToken)
+	}
+
+	return &envs, nil
+}
+
+var ErrProjectEnvironmentVariableNotFound = errors.New("project environment variable not found")
+
+// GetProjectEnvironmentVariable gets an existing project environment variable by name, with its masked value
+func (c *Client) GetProjectEnvironmentVariable(org, project, name string) (*ProjectEnvironmentVariable, error) {
+	slug, err := c.Slug(org, project)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := c.rest.NewRequest("GET", &url.URL{Path: fmt.Sprintf("project/%s/envvar/%s", slug, name)}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	env := &ProjectEnvironmentVariable{}
+	_, err = c.rest.DoRequest(req, env)
+	if err != nil {
+		if isNotFound(err) {
+			return nil, ErrProjectEnvironmentVariableNotFound
+		}
+
+		return nil, err
+	}
+
+	return env, nil
+}
+
 // HasProjectEnvironmentVariable checks for the existence of a matching project environment variable by name
 func (c *Client) HasProjectEnvironmentVariable(org, project, name string) (bool, error) {
 	slug, err := c.Slug(org, project)
@@ -31,8 +108,7 @@ func (c *Client) HasProjectEnvironmentVariable(org, project, name string) (bool,
 
 	_, err = c.rest.DoRequest(req, nil)
 	if err != nil {
-		var httpError *rest.HTTPError
-		if errors.As(err, &httpError) && httpError.Code == 404 {
+		if isNotFound(err) {
 			return false, nil
 		}
 
@@ -84,3 +160,212 @@ func (c *Client) DeleteProjectEnvironmentVariable(org, project, name string) err
 	_, err = c.rest.DoRequest(req, nil)
 	return err
 }
+
+var ErrProjectNotFound = errors.New("project not found")
+
+// Project is a CircleCI project as returned by the v2 API
+type Project struct {
+	ID               string         `json:"id"`
+	Slug             string         `json:"slug"`
+	Name             string         `json:"name"`
+	OrganizationID   string         `json:"organization_id"`
+	OrganizationName string         `json:"organization_name"`
+	OrganizationSlug string         `json:"organization_slug"`
+	VCSInfo          ProjectVCSInfo `json:"vcs_info"`
+}
+
+// ProjectVCSInfo describes the repository backing a project
+type ProjectVCSInfo struct {
+	URL           string `json:"vcs_url"`
+	Provider      string `json:"provider"`
+	DefaultBranch string `json:"default_branch"`
+}
+
+// GetProject gets an existing project by its slug
+func (c *Client) GetProject(slug string) (*Project, error) {
+	req, err := c.rest.NewRequest("GET", &url.URL{Path: fmt.Sprintf("project/%s", slug)}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	project := &Project{}
+
+	_, err = c.rest.DoRequest(req, project)
+	if err != nil {
+		if isNotFound(err) {
+			return nil, ErrProjectNotFound
+		}
+
+		return nil, err
+	}
+
+	return project, nil
+}
+
+// CreateProject sets up a new project in CircleCI and returns the created project object
+func (c *Client) CreateProject(org, project string) (*Project, error) {
+	slug, err := c.Slug(org, project)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := c.rest.NewRequest("POST", &url.URL{Path: fmt.Sprintf("project/%s", slug)}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = c.rest.DoRequest(req, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.GetProject(slug)
+}
+
+// ProjectSettings holds the settings of a project
+type ProjectSettings struct {
+	Advanced AdvancedProjectSettings `json:"advanced"`
+}
+
+// AdvancedProjectSettings holds the advanced settings of a project.
+// Unset fields are left untouched when updating settings.
+type AdvancedProjectSettings struct {
+	AutocancelBuilds           *bool     `json:"autocancel_builds,omitempty"`
+	BuildForkPRs               *bool     `json:"build_fork_prs,omitempty"`
+	BuildPRsOnly               *bool     `json:"build_prs_only,omitempty"`
+	DisableSSH                 *bool     `json:"disable_ssh,omitempty"`
+	ForksReceiveSecretEnvVars  *bool     `json:"forks_receive_secret_env_vars,omitempty"`
+	OSS                        *bool     `json:"oss,omitempty"`
+	SetGithubStatus            *bool     `json:"set_github_status,omitempty"`
+	SetupWorkflows             *bool     `json:"setup_workflows,omitempty"`
+	WriteSettingsRequiresAdmin *bool     `json:"write_settings_requires_admin,omitempty"`
+	PROnlyBranchOverrides      *[]string `json:"pr_only_branch_overrides,omitempty"`
+}
+
+// GetProjectSettings gets the advanced settings of a project
+func (c *Client) GetProjectSettings(org, project string) (*ProjectSettings, error) {
+	slug, err := c.Slug(org, project)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := c.rest.NewRequest("GET", &url.URL{Path: fmt.Sprintf("project/%s/settings", slug)}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	settings := &ProjectSettings{}
+
+	_, err = c.rest.DoRequest(req, settings)
+	if err != nil {
+		if isNotFound(err) {
+			return nil, ErrProjectNotFound
+		}
+
+		return nil, err
+	}
+
+	return settings, nil
+}
+
+// UpdateProjectSettings updates the advanced settings of a project and returns the resulting settings
+func (c *Client) UpdateProjectSettings(org, project string, settings *ProjectSettings) (*ProjectSettings, error) {
+	slug, err := c.Slug(org, project)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := c.rest.NewRequest("PATCH", &url.URL{Path: fmt.Sprintf("project/%s/settings", slug)}, settings)
+	if err != nil {
+		return nil, err
+	}
+
+	updated := &ProjectSettings{}
+
+	_, err = c.rest.DoRequest(req, updated)
+	if err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+var ErrCheckoutKeyNotFound = errors.New("checkout key not found")
+
+// CheckoutKey is a key used to check out the code of a project
+type CheckoutKey struct {
+	Fingerprint string    `json:"fingerprint"`
+	PublicKey   string    `json:"public-key"`
+	Type        string    `json:"type"`
+	Preferred   bool      `json:"preferred"`
+	CreatedAt   time.Time `json:"created-at"`
+}
+
+type createCheckoutKeyRequest struct {
+	Type string `json:"type"`
+}
+
+// CreateCheckoutKey creates a new checkout key of the given type for a project.
+// Valid types are "deploy-key" and "user-key".
+func (c *Client) CreateCheckoutKey(org, project, keyType string) (*CheckoutKey, error) {
+	slug, err := c.Slug(org, project)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := c.rest.NewRequest("POST", &url.URL{Path: fmt.Sprintf("project/%s/checkout-key", slug)}, &createCheckoutKeyRequest{
+		Type: keyType,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	key := &CheckoutKey{}
+	_, err = c.rest.DoRequest(req, key)
+	if err != nil {
+		return nil, err
+	}
+
+	return key, nil
+}
+
+// GetCheckoutKey gets an existing checkout key of a project by its fingerprint
+func (c *Client) GetCheckoutKey(org, project, fingerprint string) (*CheckoutKey, error) {
+	slug, err := c.Slug(org, project)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := c.rest.NewRequest("GET", &url.URL{Path: fmt.Sprintf("project/%s/checkout-key/%s", slug, fingerprint)}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	key := &CheckoutKey{}
+	_, err = c.rest.DoRequest(req, key)
+	if err != nil {
+		if isNotFound(err) {
+			return nil, ErrCheckoutKeyNotFound
+		}
+
+		return nil, err
+	}
+
+	return key, nil
+}
+
+// DeleteCheckoutKey deletes an existing checkout key of a project by its fingerprint
+func (c *Client) DeleteCheckoutKey(org, project, fingerprint string) error {
+	slug, err := c.Slug(org, project)
+	if err != nil {
+		return err
+	}
+
+	req, err := c.rest.NewRequest("DELETE", &url.URL{Path: fmt.Sprintf("project/%s/checkout-key/%s", slug, fingerprint)}, nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.rest.DoRequest(req, nil)
+	return err
+}
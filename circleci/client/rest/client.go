@@ -3,12 +3,10 @@ package rest
 import (
 	"bytes"
 	"encoding/json"
-	"fmt"
 	"io"
 	"net/http"
 	"net/url"
 	"strings"
-	"time"
 )
 
 type Client struct {
@@ -17,7 +15,7 @@ type Client struct {
 	client      *http.Client
 }
 
-func New(host, endpoint, circleToken string) *Client {
+func New(host, endpoint, circleToken string, client *http.Client) *Client {
 	// Ensure endpoint ends with a slash
 	if !strings.HasSuffix(endpoint, "/") {
 		endpoint += "/"
@@ -27,9 +25,7 @@ func New(host, endpoint, circleToken string) *Client {
 	return &Client{
 		baseURL:     u.ResolveReference(&url.URL{Path: endpoint}),
 		circleToken: circleToken,
-		client: &http.Client{
-			Timeout: 10 * time.Second,
-		},
+		client:      client,
 	}
 }
 
@@ -67,14 +63,7 @@ func (c *Client) DoRequest(req *http.Request, resp interface{}) (statusCode int,
 	defer httpResp.Body.Close()
 
 	if httpResp.StatusCode >= 300 {
-		httpError := struct {
-			Message string `json:"message"`
-		}{}
-		err = json.NewDecoder(httpResp.Body).Decode(&httpError)
-		if err != nil {
-			return httpResp.StatusCode, err
-		}
-		return httpResp.StatusCode, &HTTPError{Code: httpResp.StatusCode, Message: httpError.Message}
+		return httpResp.StatusCode, newHTTPError(req, httpResp)
 	}
 
 	if resp != nil {
@@ -91,18 +80,3 @@ func (c *Client) DoRequest(req *http.Request, resp interface{}) (statusCode int,
 	}
 	return httpResp.StatusCode, nil
 }
-
-type HTTPError struct {
-	Code    int
-	Message string
-}
-
-func (e *HTTPError) Error() string {
-	if e.Code == 0 {
-		e.Code = http.StatusInternalServerError
-	}
-	if e.Message != "" {
-		return e.Message
-	}
-	return fmt.Sprintf("response %d (%s)", e.Code, http.StatusText(e.Code))
-}
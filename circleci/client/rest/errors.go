@@ -0,0 +1,125 @@
+package rest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// maxErrorBody limits the size of the body kept in an HTTPError
+const maxErrorBody = 512
+
+// The kinds of HTTPError, which can be matched with errors.Is
+var (
+	ErrNotFound     = errors.New("not found")
+	ErrUnauthorized = errors.New("unauthorized")
+	ErrForbidden    = errors.New("forbidden")
+	ErrRateLimited  = errors.New("rate limited")
+	ErrConflict     = errors.New("conflict")
+	ErrServer       = errors.New("server error")
+)
+
+// HTTPError is returned for responses with an error status
+type HTTPError struct {
+	Code    int
+	Message string
+
+	// Method and Path identify the failed request
+	Method string
+	Path   string
+	// RequestID is the ID CircleCI assigned to the request, to include when contacting support
+	RequestID string
+	// Body is the beginning of the raw response body, e.g. an HTML page from a load balancer
+	Body string
+}
+
+// newHTTPError reads the error of a response. The message of the API is used when the body is
+// JSON, and the raw body is kept otherwise.
+func newHTTPError(req *http.Request, resp *http.Response) *HTTPError {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+
+	httpError := struct {
+		Message string `json:"message"`
+	}{}
+	_ = json.Unmarshal(body, &httpError)
+
+	return &HTTPError{
+		Code:      resp.StatusCode,
+		Message:   httpError.Message,
+		Method:    req.Method,
+		Path:      req.URL.Path,
+		RequestID: resp.Header.Get("X-Request-Id"),
+		Body:      strings.TrimSpace(string(body)),
+	}
+}
+
+func (e *HTTPError) Error() string {
+	msg := e.Message
+	if msg == "" {
+		code := e.Code
+		if code == 0 {
+			code = http.StatusInternalServerError
+		}
+
+		msg = fmt.Sprintf("response %d (%s)", code, http.StatusText(code))
+		if e.Body != "" {
+			msg = fmt.Sprintf("%s: %s", msg, e.Body)
+		}
+	}
+
+	if e.RequestID != "" {
+		msg = fmt.Sprintf("%s (request ID %s)", msg, e.RequestID)
+	}
+
+	return msg
+}
+
+// Is matches the kind of the error, e.g. errors.Is(err, ErrNotFound)
+func (e *HTTPError) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return e.Code == http.StatusNotFound
+	case ErrUnauthorized:
+		return e.Code == http.StatusUnauthorized
+	case ErrForbidden:
+		return e.Code == http.StatusForbidden
+	case ErrRateLimited:
+		return e.Code == http.StatusTooManyRequests
+	case ErrConflict:
+		return e.Code == http.StatusConflict
+	case ErrServer:
+		return e.Code >= 500
+	}
+
+	return false
+}
+
+// ErrorTransport turns responses with an error status into an *HTTPError, for clients which do not keep
+// the status of failed requests, such as the upstream contexts client. The error is wrapped in a *url.Error
+// by http.Client.
+type ErrorTransport struct {
+	// Transport sends the requests. When nil, http.DefaultTransport is used.
+	Transport http.RoundTripper
+}
+
+func (t *ErrorTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	transport := t.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode < 300 {
+		return resp, nil
+	}
+
+	defer resp.Body.Close()
+	return nil, newHTTPError(req, resp)
+}
@@ -0,0 +1,95 @@
+package rest
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDoRequestHTTPError(t *testing.T) {
+	cases := []struct {
+		name    string
+		status  int
+		body    string
+		kind    error
+		message string
+	}{
+		{"not found", 404, `{"message":"Project not found"}`, ErrNotFound, "Project not found (request ID 1234)"},
+		{"unauthorized", 401, `{"message":"Invalid token provided."}`, ErrUnauthorized, "Invalid token provided. (request ID 1234)"},
+		{"forbidden", 403, `{"message":"Permission denied"}`, ErrForbidden, "Permission denied (request ID 1234)"},
+		{"rate limited", 429, `{"message":"Too many requests"}`, ErrRateLimited, "Too many requests (request ID 1234)"},
+		{"conflict", 409, `{"message":"Already exists"}`, ErrConflict, "Already exists (request ID 1234)"},
+		{"server error without JSON", 502, "<html>Bad Gateway</html>", ErrServer, "response 502 (Bad Gateway): <html>Bad Gateway</html> (request ID 1234)"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("X-Request-Id", "1234")
+				w.WriteHeader(tc.status)
+				_, _ = w.Write([]byte(tc.body))
+			}))
+			defer server.Close()
+
+			client := New(server.URL, "/api/v2", "token", http.DefaultClient)
+			req, _ := client.NewRequest("GET", &url.URL{Path: "project/github/org/repo"}, nil)
+
+			status, err := client.DoRequest(req, nil)
+			assert.Equal(t, tc.status, status)
+
+			wrapped := fmt.Errorf("error: %w", err)
+			assert.True(t, errors.Is(wrapped, tc.kind))
+			assert.EqualError(t, err, tc.message)
+
+			var httpError *HTTPError
+			if assert.True(t, errors.As(wrapped, &httpError)) {
+				assert.Equal(t, "1234", httpError.RequestID)
+				assert.Equal(t, tc.body, httpError.Body)
+			}
+		})
+	}
+}
+
+func TestHTTPErrorWithoutCode(t *testing.T) {
+	err := &HTTPError{}
+
+	// Formatting the error does not change it
+	assert.EqualError(t, err, "response 500 (Internal Server Error)")
+	assert.Equal(t, 0, err.Code)
+}
+
+func TestErrorTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/forbidden" {
+			w.Header().Set("X-Request-Id", "1234")
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"message":"Permission denied"}`))
+			return
+		}
+
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: &ErrorTransport{}}
+
+	resp, err := client.Get(server.URL + "/ok")
+	if assert.NoError(t, err) {
+		resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	}
+
+	_, err = client.Get(server.URL + "/forbidden")
+	assert.ErrorIs(t, err, ErrForbidden)
+
+	var httpError *HTTPError
+	if assert.ErrorAs(t, err, &httpError) {
+		assert.Equal(t, "1234", httpError.RequestID)
+		assert.Equal(t, "Permission denied", httpError.Message)
+	}
+}
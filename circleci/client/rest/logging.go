@@ -0,0 +1,155 @@
+package rest
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// maxLoggedBody limits the size of the request and response bodies written to the logs
+const maxLoggedBody = 4096
+
+const redacted = "REDACTED"
+
+// redactedHeaders hold credentials, which are never logged
+var redactedHeaders = []string{"Circle-Token", "Authorization"}
+
+// redactedFields are the JSON fields holding secrets, such as the values of environment variables. Fields
+// containing one of redactedWords, such as signing-secret or private_key, are redacted as well.
+var redactedFields = map[string]bool{
+	"value": true,
+}
+
+var redactedWords = []string{"secret", "key", "token", "password"}
+
+// redactedField returns whether a JSON field holds a secret, ignoring case and separators
+func redactedField(name string) bool {
+	name = strings.ToLower(strings.NewReplacer("-", "", "_", "").Replace(name))
+	if redactedFields[name] {
+		return true
+	}
+
+	for _, word := range redactedWords {
+		if strings.Contains(name, word) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// LoggingTransport logs each request and response at the debug level, without credentials
+// or the values of environment variables
+type LoggingTransport struct {
+	// Transport sends the requests. When nil, http.DefaultTransport is used.
+	Transport http.RoundTripper
+}
+
+func (t *LoggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	transport := t.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	log.Printf("[DEBUG] CircleCI API request: %s %s\nHeaders: %s\nBody: %s",
+		req.Method, req.URL.Redacted(), redactHeaders(req.Header), requestBody(req))
+
+	start := time.Now()
+	resp, err := transport.RoundTrip(req)
+	latency := time.Since(start)
+
+	if err != nil {
+		log.Printf("[DEBUG] CircleCI API request failed: %s %s (%s): %s", req.Method, req.URL.Redacted(), latency, err)
+		return nil, err
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	log.Printf("[DEBUG] CircleCI API response: %s %s: %s (%s, request ID %q)\nBody: %s",
+		req.Method, req.URL.Redacted(), resp.Status, latency, resp.Header.Get("X-Request-Id"), redactBody(body))
+
+	return resp, nil
+}
+
+// requestBody returns the redacted body of a request, without consuming it
+func requestBody(req *http.Request) string {
+	if req.Body == nil || req.GetBody == nil {
+		return ""
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return ""
+	}
+	defer body.Close()
+
+	b, err := io.ReadAll(body)
+	if err != nil {
+		return ""
+	}
+
+	return redactBody(b)
+}
+
+func redactHeaders(header http.Header) http.Header {
+	header = header.Clone()
+	for _, name := range redactedHeaders {
+		if header.Get(name) != "" {
+			header.Set(name, redacted)
+		}
+	}
+
+	return header
+}
+
+// redactBody replaces the secret fields of a JSON body. Bodies which are not JSON are not logged,
+// since their secrets cannot be found.
+func redactBody(body []byte) string {
+	if len(bytes.TrimSpace(body)) == 0 {
+		return ""
+	}
+
+	var v interface{}
+	if err := json.Unmarshal(body, &v); err != nil {
+		return "(not JSON, " + http.DetectContentType(body) + ")"
+	}
+
+	b, err := json.Marshal(redactValue(v))
+	if err != nil {
+		return ""
+	}
+
+	if len(b) > maxLoggedBody {
+		return string(b[:maxLoggedBody]) + "..."
+	}
+
+	return string(b)
+}
+
+func redactValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for key, value := range v {
+			if _, ok := value.(string); ok && redactedField(key) {
+				v[key] = redacted
+			} else {
+				v[key] = redactValue(value)
+			}
+		}
+	case []interface{}:
+		for i, value := range v {
+			v[i] = redactValue(value)
+		}
+	}
+
+	return v
+}
@@ -0,0 +1,57 @@
+package rest
+
+import (
+	"bytes"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoggingTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "7b3c1c6e")
+		_, _ = w.Write([]byte(`{"items":[{"name":"FOO","value":"xxxxbar"}]}`))
+	}))
+	defer server.Close()
+
+	var logs bytes.Buffer
+	log.SetOutput(&logs)
+	defer log.SetOutput(os.Stderr)
+
+	client := &http.Client{Transport: &LoggingTransport{}}
+
+	req, _ := http.NewRequest("PUT", server.URL+"/context/1/environment-variable/FOO", strings.NewReader(`{"value":"secret-value"}`))
+	req.Header.Set("Circle-Token", "secret-token")
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	// The body is still available to the caller after being logged
+	body, _ := io.ReadAll(resp.Body)
+	assert.Equal(t, `{"items":[{"name":"FOO","value":"xxxxbar"}]}`, string(body))
+
+	assert.Contains(t, logs.String(), "PUT "+server.URL+"/context/1/environment-variable/FOO")
+	assert.Contains(t, logs.String(), `200 OK`)
+	assert.Contains(t, logs.String(), `request ID "7b3c1c6e"`)
+	assert.Contains(t, logs.String(), `{"items":[{"name":"FOO","value":"REDACTED"}]}`)
+	assert.NotContains(t, logs.String(), "secret-token")
+	assert.NotContains(t, logs.String(), "secret-value")
+	assert.NotContains(t, logs.String(), "xxxxbar")
+}
+
+func TestRedactBody(t *testing.T) {
+	assert.Equal(t, `{"name":"FOO","value":"REDACTED"}`, redactBody([]byte(`{"name":"FOO","value":"bar"}`)))
+	assert.Equal(t, `[{"private_key":"REDACTED"}]`, redactBody([]byte(`[{"private_key":"-----BEGIN"}]`)))
+	assert.Equal(t, `{"name":"deploy","signing-secret":"REDACTED","url":"https://example.com"}`, redactBody([]byte(`{"name":"deploy","signing-secret":"supersecret","url":"https://example.com"}`)))
+	assert.Equal(t, `{"Circle_Token":"REDACTED","apiKey":"REDACTED"}`, redactBody([]byte(`{"Circle_Token":"abc","apiKey":"def"}`)))
+	assert.Equal(t, "(not JSON, text/plain; charset=utf-8)", redactBody([]byte("value=bar")))
+	assert.Equal(t, "", redactBody(nil))
+}
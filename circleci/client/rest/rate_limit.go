@@ -0,0 +1,80 @@
+package rest
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// RateLimiter is a token bucket limiting the rate of requests. It is safe for concurrent use,
+// so that a single limiter can throttle all the requests of concurrent resource operations.
+type RateLimiter struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// NewRateLimiter returns a limiter allowing the given number of requests per second,
+// which is also the number of requests that can be sent at once after a pause
+func NewRateLimiter(requestsPerSecond int) *RateLimiter {
+	return &RateLimiter{
+		rate:   float64(requestsPerSecond),
+		burst:  float64(requestsPerSecond),
+		tokens: float64(requestsPerSecond),
+		last:   time.Now(),
+	}
+}
+
+// Wait blocks until a request can be sent, or until the context is done
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	l.mu.Lock()
+	now := time.Now()
+	l.tokens = min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+	l.last = now
+
+	// The token is reserved right away, so that waiting requests are sent in order
+	l.tokens--
+	delay := time.Duration(-l.tokens / l.rate * float64(time.Second))
+	l.mu.Unlock()
+
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		l.mu.Lock()
+		l.tokens++
+		l.mu.Unlock()
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// RateLimitTransport waits for a rate limiter before sending each request
+type RateLimitTransport struct {
+	// Transport sends the requests. When nil, http.DefaultTransport is used.
+	Transport http.RoundTripper
+
+	Limiter *RateLimiter
+}
+
+func (t *RateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	transport := t.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	if err := t.Limiter.Wait(req.Context()); err != nil {
+		return nil, err
+	}
+
+	return transport.RoundTrip(req)
+}
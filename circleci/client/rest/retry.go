@@ -0,0 +1,178 @@
+package rest
+
+import (
+	"context"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	minBackoff = 1 * time.Second
+	maxBackoff = 30 * time.Second
+)
+
+// RetryTransport retries requests which were rate limited or failed with a transient error.
+// Rate limited requests are always retried, since the API did not process them. Other failures
+// are only retried for idempotent methods.
+type RetryTransport struct {
+	// Transport sends the requests. When nil, http.DefaultTransport is used.
+	Transport http.RoundTripper
+
+	// MaxRetries is the number of times a request is retried
+	MaxRetries int
+
+	// Timeout limits each attempt. When zero, attempts are not limited.
+	Timeout time.Duration
+}
+
+func (t *RetryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		resp, err := t.roundTrip(req)
+
+		if attempt >= t.MaxRetries || !retryable(req, resp, err) {
+			return resp, err
+		}
+
+		// The body of a request can only be sent again when it can be recreated
+		if req.Body != nil && req.GetBody == nil {
+			return resp, err
+		}
+
+		delay := backoff(attempt)
+		if resp != nil {
+			if after, ok := retryAfter(resp, time.Now()); ok {
+				delay = after
+			}
+
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		select {
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		case <-time.After(delay):
+		}
+	}
+}
+
+// roundTrip sends a single attempt of the request
+func (t *RetryTransport) roundTrip(req *http.Request) (*http.Response, error) {
+	transport := t.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	attempt := req.Clone(req.Context())
+	if req.Body != nil && req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		attempt.Body = body
+	}
+
+	if t.Timeout == 0 {
+		return transport.RoundTrip(attempt)
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), t.Timeout)
+	resp, err := transport.RoundTrip(attempt.WithContext(ctx))
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	// The timeout also applies to reading the body, so it is only released once the body is closed
+	resp.Body = &cancelOnClose{ReadCloser: resp.Body, cancel: cancel}
+	return resp, nil
+}
+
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelOnClose) Close() error {
+	defer b.cancel()
+	return b.ReadCloser.Close()
+}
+
+// retryable returns whether a failed attempt can be retried
+func retryable(req *http.Request, resp *http.Response, err error) bool {
+	if req.Context().Err() != nil {
+		return false
+	}
+
+	if err != nil {
+		return idempotent(req.Method)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests:
+		return true
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return idempotent(req.Method)
+	}
+
+	return false
+}
+
+func idempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+
+	return false
+}
+
+// backoff returns the exponential delay before the next attempt, with jitter so that
+// concurrent requests do not retry at the same time
+func backoff(attempt int) time.Duration {
+	delay := maxBackoff
+	if attempt < 5 {
+		delay = min(minBackoff<<attempt, maxBackoff)
+	}
+
+	return delay/2 + rand.N(delay/2)
+}
+
+// retryAfter returns how long the API asked to wait before retrying, from either the Retry-After
+// header or the X-RateLimit-Reset header of an exhausted rate limit. The delay is capped at maxBackoff,
+// so that a single header cannot stall an apply for hours.
+func retryAfter(resp *http.Response, now time.Time) (time.Duration, bool) {
+	delay, ok := requestedDelay(resp, now)
+	return min(delay, maxBackoff), ok
+}
+
+func requestedDelay(resp *http.Response, now time.Time) (time.Duration, bool) {
+	if value := resp.Header.Get("Retry-After"); value != "" {
+		if seconds, err := strconv.Atoi(value); err == nil {
+			return time.Duration(max(seconds, 0)) * time.Second, true
+		}
+
+		if date, err := http.ParseTime(value); err == nil {
+			return max(date.Sub(now), 0), true
+		}
+	}
+
+	if resp.Header.Get("X-RateLimit-Remaining") != "0" {
+		return 0, false
+	}
+
+	reset, err := strconv.ParseInt(resp.Header.Get("X-RateLimit-Reset"), 10, 64)
+	if err != nil {
+		return 0, false
+	}
+
+	// The reset is either a Unix timestamp or a number of seconds
+	if reset > 1e9 {
+		return max(time.Unix(reset, 0).Sub(now), 0), true
+	}
+
+	return time.Duration(max(reset, 0)) * time.Second, true
+}
@@ -0,0 +1,91 @@
+package rest
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetryTransport(t *testing.T) {
+	cases := []struct {
+		name     string
+		method   string
+		statuses []int
+		attempts int
+		status   int
+	}{
+		{"rate limited", "POST", []int{429, 429, 200}, 3, 200},
+		{"transient error", "GET", []int{502, 503, 200}, 3, 200},
+		{"transient error of a POST", "POST", []int{502, 200}, 1, 502},
+		{"client error", "GET", []int{404, 200}, 1, 404},
+		{"too many failures", "GET", []int{429, 429, 429, 429, 429}, 4, 429},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var bodies []string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+				bodies = append(bodies, string(body))
+
+				w.Header().Set("Retry-After", "0")
+				w.WriteHeader(tc.statuses[len(bodies)-1])
+			}))
+			defer server.Close()
+
+			client := &http.Client{Transport: &RetryTransport{MaxRetries: 3, Timeout: time.Second}}
+
+			req, _ := http.NewRequest(tc.method, server.URL, strings.NewReader("payload"))
+			resp, err := client.Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer resp.Body.Close()
+
+			assert.Equal(t, tc.status, resp.StatusCode)
+			assert.Len(t, bodies, tc.attempts)
+			for _, body := range bodies {
+				assert.Equal(t, "payload", body)
+			}
+		})
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		header http.Header
+		delay  time.Duration
+		ok     bool
+	}{
+		{http.Header{"Retry-After": {"5"}}, 5 * time.Second, true},
+		{http.Header{"Retry-After": {"Mon, 01 Jan 2024 00:00:30 GMT"}}, 30 * time.Second, true},
+		{http.Header{"X-Ratelimit-Remaining": {"0"}, "X-Ratelimit-Reset": {"7"}}, 7 * time.Second, true},
+		{http.Header{"X-Ratelimit-Remaining": {"0"}, "X-Ratelimit-Reset": {"1704067212"}}, 12 * time.Second, true},
+		{http.Header{"X-Ratelimit-Remaining": {"10"}, "X-Ratelimit-Reset": {"7"}}, 0, false},
+		{http.Header{"Retry-After": {"86400"}}, maxBackoff, true},
+		{http.Header{"Retry-After": {"Tue, 02 Jan 2024 00:00:00 GMT"}}, maxBackoff, true},
+		{http.Header{"X-Ratelimit-Remaining": {"0"}, "X-Ratelimit-Reset": {"1704153600"}}, maxBackoff, true},
+		{http.Header{}, 0, false},
+	}
+
+	for _, tc := range cases {
+		delay, ok := retryAfter(&http.Response{Header: tc.header}, now)
+		assert.Equal(t, tc.ok, ok, tc.header)
+		assert.Equal(t, tc.delay, delay, tc.header)
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	for attempt := 0; attempt < 10; attempt++ {
+		delay := backoff(attempt)
+		assert.GreaterOrEqual(t, delay, minBackoff/2)
+		assert.LessOrEqual(t, delay, maxBackoff)
+	}
+}
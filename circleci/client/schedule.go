@@ -0,0 +1,148 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+var ErrScheduleNotFound = errors.New("schedule not found")
+
+// Schedule is a scheduled pipeline of a project
+type Schedule struct {
+	ID               string                 `json:"id,omitempty"`
+	Name             string                 `json:"name"`
+	Description      string                 `json:"description"`
+	ProjectSlug      string                 `json:"project-slug,omitempty"`
+	Timetable        Timetable              `json:"timetable"`
+	Parameters       map[string]interface{} `json:"parameters"`
+	AttributionActor string                 `json:"attribution-actor,omitempty"`
+	Actor            *ScheduleActor         `json:"actor,omitempty"`
+	CreatedAt        *time.Time             `json:"created-at,omitempty"`
+	UpdatedAt        *time.Time             `json:"updated-at,omitempty"`
+}
+
+// Timetable describes when a scheduled pipeline is triggered
+type Timetable struct {
+	PerHour     int      `json:"per-hour"`
+	HoursOfDay  []int    `json:"hours-of-day"`
+	DaysOfWeek  []string `json:"days-of-week,omitempty"`
+	DaysOfMonth []int    `json:"days-of-month,omitempty"`
+	Months      []string `json:"months,omitempty"`
+}
+
+// ScheduleActor is the user a scheduled pipeline is attributed to
+type ScheduleActor struct {
+	ID    string `json:"id"`
+	Login string `json:"login"`
+	Name  string `json:"name"`
+}
+
+// CreateSchedule creates a new scheduled pipeline for a project and returns the created schedule
+func (c *Client) CreateSchedule(org, project string, schedule *Schedule) (*Schedule, error) {
+	if err := c.require(CapabilitySchedules); err != nil {
+		return nil, err
+	}
+
+	slug, err := c.Slug(org, project)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := c.rest.NewRequest("POST", &url.URL{Path: fmt.Sprintf("project/%s/schedule", slug)}, schedule)
+	if err != nil {
+		return nil, err
+	}
+
+	created := &Schedule{}
+	_, err = c.rest.DoRequest(req, created)
+	if err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
+// GetSchedule gets an existing scheduled pipeline by its ID (UUID)
+func (c *Client) GetSchedule(id string) (*Schedule, error) {
+	if err := c.require(CapabilitySchedules); err != nil {
+		return nil, err
+	}
+
+	req, err := c.rest.NewRequest("GET", &url.URL{Path: fmt.Sprintf("schedule/%s", id)}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	schedule := &Schedule{}
+	_, err = c.rest.DoRequest(req, schedule)
+	if err != nil {
+		if isNotFound(err) {
+			return nil, ErrScheduleNotFound
+		}
+
+		return nil, err
+	}
+
+	return schedule, nil
+}
+
+// ScheduleAttributionActor returns the actor a scheduled pipeline is attributed to, either current or system.
+// The API only returns the actor itself, which is the current user when the schedule is attributed to the
+// owner of the token.
+func (c *Client) ScheduleAttributionActor(schedule *Schedule) (string, error) {
+	if schedule.AttributionActor != "" {
+		return schedule.AttributionActor, nil
+	}
+
+	if schedule.Actor == nil {
+		return "", nil
+	}
+
+	user, err := c.GetCurrentUser()
+	if err != nil {
+		return "", err
+	}
+
+	if schedule.Actor.ID == user.ID {
+		return "current", nil
+	}
+
+	return "system", nil
+}
+
+// UpdateSchedule updates an existing scheduled pipeline and returns the updated schedule
+func (c *Client) UpdateSchedule(id string, schedule *Schedule) (*Schedule, error) {
+	if err := c.require(CapabilitySchedules); err != nil {
+		return nil, err
+	}
+
+	req, err := c.rest.NewRequest("PATCH", &url.URL{Path: fmt.Sprintf("schedule/%s", id)}, schedule)
+	if err != nil {
+		return nil, err
+	}
+
+	updated := &Schedule{}
+	_, err = c.rest.DoRequest(req, updated)
+	if err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+// DeleteSchedule deletes an existing scheduled pipeline by its ID (UUID)
+func (c *Client) DeleteSchedule(id string) error {
+	if err := c.require(CapabilitySchedules); err != nil {
+		return err
+	}
+
+	req, err := c.rest.NewRequest("DELETE", &url.URL{Path: fmt.Sprintf("schedule/%s", id)}, nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.rest.DoRequest(req, nil)
+	return err
+}
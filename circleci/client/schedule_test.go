@@ -0,0 +1,39 @@
+package client
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScheduleAttributionActor(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v2/me", r.URL.Path)
+		_, _ = w.Write([]byte(`{"id": "6d87b798-5edb-4d99-b424-ce73b43affb9", "login": "user", "name": "User"}`))
+	}))
+	defer server.Close()
+
+	c, err := New(Config{URL: server.URL + "/api/v2/", Token: "token", VCS: "github"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		schedule *Schedule
+		actor    string
+	}{
+		{&Schedule{Actor: &ScheduleActor{ID: "6d87b798-5edb-4d99-b424-ce73b43affb9", Login: "user"}}, "current"},
+		{&Schedule{Actor: &ScheduleActor{ID: "d9b3fcaa-6032-405a-8c70-2b8c6e6e8b9b", Login: "system-actor"}}, "system"},
+		{&Schedule{AttributionActor: "system"}, "system"},
+		{&Schedule{}, ""},
+	}
+
+	for _, tc := range cases {
+		actor, err := c.ScheduleAttributionActor(tc.schedule)
+		if assert.NoError(t, err) {
+			assert.Equal(t, tc.actor, actor)
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"sync"
+
+	"github.com/mrolla/terraform-provider-circleci/circleci/client/rest"
+)
+
+// ErrUnsupported is returned for features which are not available on the CircleCI server installation
+var ErrUnsupported = errors.New("not supported by this CircleCI server installation")
+
+// Capability is an API feature which is missing from some CircleCI server versions
+type Capability string
+
+const (
+	CapabilityWebhooks            Capability = "webhooks"
+	CapabilitySchedules           Capability = "scheduled pipelines"
+	CapabilityContextRestrictions Capability = "context restrictions"
+)
+
+// nilID is used to probe endpoints without referring to an existing object
+const nilID = "00000000-0000-0000-0000-000000000000"
+
+// capabilityProbes are requests answered by the API when a capability is supported
+var capabilityProbes = map[Capability]*url.URL{
+	CapabilityWebhooks:            {Path: "webhook", RawQuery: url.Values{"scope-type": {"project"}, "scope-id": {nilID}}.Encode()},
+	CapabilitySchedules:           {Path: fmt.Sprintf("schedule/%s", nilID)},
+	CapabilityContextRestrictions: {Path: fmt.Sprintf("context/%s/restrictions", nilID)},
+}
+
+// capabilities caches the detected capabilities of a CircleCI server installation
+type capabilities struct {
+	mu        sync.Mutex
+	supported map[Capability]bool
+}
+
+// Supports returns whether the API supports a capability. CircleCI cloud supports all of them, while
+// they are detected once for a CircleCI server installation.
+func (c *Client) Supports(capability Capability) (bool, error) {
+	if !c.server {
+		return true, nil
+	}
+
+	c.capabilities.mu.Lock()
+	defer c.capabilities.mu.Unlock()
+
+	if supported, ok := c.capabilities.supported[capability]; ok {
+		return supported, nil
+	}
+
+	supported, err := c.probe(capabilityProbes[capability])
+	if err != nil {
+		return false, fmt.Errorf("failed to detect support for %s: %w", capability, err)
+	}
+
+	c.capabilities.supported[capability] = supported
+	return supported, nil
+}
+
+// require returns an error when a capability is not supported
+func (c *Client) require(capability Capability) error {
+	supported, err := c.Supports(capability)
+	if err != nil {
+		return err
+	}
+
+	if !supported {
+		return fmt.Errorf("%s are %w at %s", capability, ErrUnsupported, c.host)
+	}
+
+	return nil
+}
+
+// probe sends a request for an object which does not exist. The API answers with an error message
+// when it serves the endpoint, while the server answers unknown endpoints with a 404 and no message.
+func (c *Client) probe(u *url.URL) (bool, error) {
+	req, err := c.rest.NewRequest("GET", u, nil)
+	if err != nil {
+		return false, err
+	}
+
+	_, err = c.rest.DoRequest(req, nil)
+
+	var httpError *rest.HTTPError
+	switch {
+	case err == nil:
+		return true, nil
+	case errors.As(err, &httpError):
+		if httpError.Code == 404 {
+			return httpError.Message != "", nil
+		}
+
+		// Other client errors, e.g. for the invalid ID, come from the endpoint
+		if httpError.Code >= 400 && httpError.Code < 500 && httpError.Code != 401 {
+			return true, nil
+		}
+	}
+
+	return false, err
+}
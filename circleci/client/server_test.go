@@ -0,0 +1,81 @@
+package client
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServerCapabilities(t *testing.T) {
+	var probes int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/api/v2/schedule/"):
+			// This server version does not serve scheduled pipelines
+			probes++
+			http.NotFound(w, r)
+		case strings.HasPrefix(r.URL.Path, "/api/v2/webhook"):
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"message":"Invalid scope-id"}`))
+		case strings.HasPrefix(r.URL.Path, "/api/v2/context/"):
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message":"Context not found"}`))
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	c, err := New(Config{Host: server.URL, Token: "token", VCS: "github", Organization: "org"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = c.GetSchedule("1234")
+	assert.True(t, errors.Is(err, ErrUnsupported))
+	assert.EqualError(t, err, "scheduled pipelines are not supported by this CircleCI server installation at "+server.URL)
+
+	// The capability is only detected once
+	_, err = c.GetSchedule("1234")
+	assert.True(t, errors.Is(err, ErrUnsupported))
+	assert.Equal(t, 1, probes)
+
+	supported, err := c.Supports(CapabilityWebhooks)
+	assert.NoError(t, err)
+	assert.True(t, supported)
+
+	supported, err = c.Supports(CapabilityContextRestrictions)
+	assert.NoError(t, err)
+	assert.True(t, supported)
+}
+
+func TestCloudCapabilities(t *testing.T) {
+	c, err := New(Config{URL: "https://circleci.com/api/v2/", Token: "token"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// CircleCI cloud is not probed
+	supported, err := c.Supports(CapabilitySchedules)
+	assert.NoError(t, err)
+	assert.True(t, supported)
+}
+
+func TestAPIURL(t *testing.T) {
+	root, restPath, err := apiURL(Config{URL: "https://circleci.com/api/v2/"})
+	assert.NoError(t, err)
+	assert.Equal(t, "https://circleci.com", root)
+	assert.Equal(t, "/api/v2/", restPath)
+
+	root, restPath, err = apiURL(Config{URL: "https://circleci.com/api/v2/", Host: "https://circleci.example.com", RESTEndpoint: "custom/api/v2"})
+	assert.NoError(t, err)
+	assert.Equal(t, "https://circleci.example.com", root)
+	assert.Equal(t, "/custom/api/v2/", restPath)
+
+	_, _, err = apiURL(Config{Host: "circleci.example.com"})
+	assert.EqualError(t, err, `invalid host "circleci.example.com", expected a URL such as https://circleci.example.com`)
+}
@@ -0,0 +1,86 @@
+package client
+
+import (
+	"fmt"
+	"net/url"
+)
+
+type sshKey struct {
+	Hostname    string `json:"hostname"`
+	PrivateKey  string `json:"private_key,omitempty"`
+	PublicKey   string `json:"public_key,omitempty"`
+	Fingerprint string `json:"fingerprint,omitempty"`
+}
+
+type projectSettingsV1 struct {
+	SSHKeys []sshKey `json:"ssh_keys"`
+}
+
+// HasSSHKey checks for the existence of an additional SSH key of a project by hostname and fingerprint
+func (c *Client) HasSSHKey(org, project, hostname, fingerprint string) (bool, error) {
+	slug, err := c.Slug(org, project)
+	if err != nil {
+		return false, err
+	}
+
+	req, err := c.restV1.NewRequest("GET", &url.URL{Path: fmt.Sprintf("project/%s/settings", slug)}, nil)
+	if err != nil {
+		return false, err
+	}
+
+	settings := &projectSettingsV1{}
+	_, err = c.restV1.DoRequest(req, settings)
+	if err != nil {
+		if isNotFound(err) {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	for _, key := range settings.SSHKeys {
+		if key.Hostname == hostname && key.Fingerprint == fingerprint {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// CreateSSHKey adds an additional SSH key for a hostname to a project
+func (c *Client) CreateSSHKey(org, project, hostname, privateKey string) error {
+	slug, err := c.Slug(org, project)
+	if err != nil {
+		return err
+	}
+
+	req, err := c.restV1.NewRequest("POST", &url.URL{Path: fmt.Sprintf("project/%s/ssh-key", slug)}, &sshKey{
+		Hostname:   hostname,
+		PrivateKey: privateKey,
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = c.restV1.DoRequest(req, nil)
+	return err
+}
+
+// DeleteSSHKey deletes an additional SSH key of a project by hostname and fingerprint
+func (c *Client) DeleteSSHKey(org, project, hostname, fingerprint string) error {
+	slug, err := c.Slug(org, project)
+	if err != nil {
+		return err
+	}
+
+	req, err := c.restV1.NewRequest("DELETE", &url.URL{Path: fmt.Sprintf("project/%s/ssh-key", slug)}, &sshKey{
+		Hostname:    hostname,
+		Fingerprint: fingerprint,
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = c.restV1.DoRequest(req, nil)
+	return err
+}
@@ -0,0 +1,58 @@
+package client
+
+import (
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewTransportCACertFile(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	caCertFile := filepath.Join(t.TempDir(), "ca.pem")
+	caCert := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	if err := os.WriteFile(caCertFile, caCert, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	transport, err := newTransport(Config{})
+	assert.NoError(t, err)
+	_, err = (&http.Client{Transport: transport}).Get(server.URL)
+	assert.Error(t, err, "the certificate of the server is not trusted")
+
+	transport, err = newTransport(Config{CACertFile: caCertFile})
+	assert.NoError(t, err)
+	resp, err := (&http.Client{Transport: transport}).Get(server.URL)
+	if assert.NoError(t, err) {
+		resp.Body.Close()
+	}
+}
+
+func TestNewTransportInvalid(t *testing.T) {
+	_, err := newTransport(Config{CACertFile: filepath.Join(t.TempDir(), "missing.pem")})
+	assert.Error(t, err)
+
+	_, err = newTransport(Config{ClientCertFile: "client.pem"})
+	assert.EqualError(t, err, "a client certificate requires both a certificate and a key")
+
+	_, err = newTransport(Config{ProxyURL: "://proxy"})
+	assert.Error(t, err)
+}
+
+func TestNewTransportProxyURL(t *testing.T) {
+	transport, err := newTransport(Config{ProxyURL: "http://proxy.example.com:3128"})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	req, _ := http.NewRequest("GET", "https://circleci.com/api/v2/me", nil)
+	proxy, err := transport.Proxy(req)
+	assert.NoError(t, err)
+	assert.Equal(t, "http://proxy.example.com:3128", proxy.String())
+}
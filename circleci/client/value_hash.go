@@ -0,0 +1,21 @@
+package client
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+)
+
+// HashValue hashes the value of an environment variable before it is stored in the state. With a
+// value hash key, it is an HMAC, so that the state cannot be used to confirm guesses of a value.
+// Without a key it is a plain SHA-256 checksum, so existing states keep matching.
+func (c *Client) HashValue(value string) string {
+	if c.valueHashKey == "" {
+		hash := sha256.Sum256([]byte(value))
+		return base64.StdEncoding.EncodeToString(hash[:])
+	}
+
+	mac := hmac.New(sha256.New, []byte(c.valueHashKey))
+	mac.Write([]byte(value))
+	return "hmac-sha256:" + base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,150 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+var ErrWebhookNotFound = errors.New("webhook not found")
+
+// Webhook is an outbound webhook notifying an URL about events of a scope
+type Webhook struct {
+	ID        string        `json:"id,omitempty"`
+	Name      string        `json:"name"`
+	URL       string        `json:"url"`
+	Events    []string      `json:"events"`
+	VerifyTLS bool          `json:"verify-tls"`
+	Scope     *WebhookScope `json:"scope,omitempty"`
+	// The API only returns a masked signing secret, and leaves it unchanged on update when empty
+	SigningSecret string     `json:"signing-secret,omitempty"`
+	CreatedAt     *time.Time `json:"created-at,omitempty"`
+	UpdatedAt     *time.Time `json:"updated-at,omitempty"`
+}
+
+// WebhookScope is the entity a webhook receives events for
+type WebhookScope struct {
+	ID   string `json:"id"`
+	Type string `json:"type"`
+}
+
+type listWebhooksResponse struct {
+	Items         []Webhook `json:"items"`
+	NextPageToken string    `json:"next_page_token"`
+}
+
+// CreateWebhook creates a new webhook and returns the created webhook object
+func (c *Client) CreateWebhook(webhook *Webhook) (*Webhook, error) {
+	if err := c.require(CapabilityWebhooks); err != nil {
+		return nil, err
+	}
+
+	req, err := c.rest.NewRequest("POST", &url.URL{Path: "webhook"}, webhook)
+	if err != nil {
+		return nil, err
+	}
+
+	created := &Webhook{}
+	_, err = c.rest.DoRequest(req, created)
+	if err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
+// GetWebhook gets an existing webhook by its ID (UUID)
+func (c *Client) GetWebhook(id string) (*Webhook, error) {
+	if err := c.require(CapabilityWebhooks); err != nil {
+		return nil, err
+	}
+
+	req, err := c.rest.NewRequest("GET", &url.URL{Path: fmt.Sprintf("webhook/%s", id)}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	webhook := &Webhook{}
+	_, err = c.rest.DoRequest(req, webhook)
+	if err != nil {
+		if isNotFound(err) {
+			return nil, ErrWebhookNotFound
+		}
+
+		return nil, err
+	}
+
+	return webhook, nil
+}
+
+// ListWebhooks lists all webhooks of a project by the project ID (UUID)
+func (c *Client) ListWebhooks(projectID string) ([]Webhook, error) {
+	if err := c.require(CapabilityWebhooks); err != nil {
+		return nil, err
+	}
+
+	var webhooks []Webhook
+
+	params := url.Values{}
+	params.Set("scope-id", projectID)
+	params.Set("scope-type", "project")
+
+	for {
+		req, err := c.rest.NewRequest("GET", &url.URL{Path: "webhook", RawQuery: params.Encode()}, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		resp := &listWebhooksResponse{}
+		_, err = c.rest.DoRequest(req, resp)
+		if err != nil {
+			return nil, err
+		}
+
+		webhooks = append(webhooks, resp.Items...)
+
+		if resp.NextPageToken == "" {
+			break
+		}
+
+		params.Set("page-token", resp.NextPageToken)
+	}
+
+	return webhooks, nil
+}
+
+// UpdateWebhook updates an existing webhook and returns the updated webhook object
+func (c *Client) UpdateWebhook(id string, webhook *Webhook) (*Webhook, error) {
+	if err := c.require(CapabilityWebhooks); err != nil {
+		return nil, err
+	}
+
+	req, err := c.rest.NewRequest("PUT", &url.URL{Path: fmt.Sprintf("webhook/%s", id)}, webhook)
+	if err != nil {
+		return nil, err
+	}
+
+	updated := &Webhook{}
+	_, err = c.rest.DoRequest(req, updated)
+	if err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+// DeleteWebhook deletes an existing webhook by its ID (UUID)
+func (c *Client) DeleteWebhook(id string) error {
+	if err := c.require(CapabilityWebhooks); err != nil {
+		return err
+	}
+
+	req, err := c.rest.NewRequest("DELETE", &url.URL{Path: fmt.Sprintf("webhook/%s", id)}, nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.rest.DoRequest(req, nil)
+	return err
+}
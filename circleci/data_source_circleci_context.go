@@ -1,42 +1,128 @@
 package circleci
 
 import (
-	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 
 	client "github.com/mrolla/terraform-provider-circleci/circleci/client"
 )
 
-func dataSourceCircleCIContext() *schema.Resource {
-	return &schema.Resource{
-		Read: dataSourceCircleCIContextRead,
+var _ datasource.DataSourceWithConfigure = &contextDataSource{}
+
+type contextDataSource struct {
+	client *client.Client
+}
+
+type contextDataSourceModel struct {
+	ID           types.String                        `tfsdk:"id"`
+	Name         types.String                        `tfsdk:"name"`
+	Organization types.String                        `tfsdk:"organization"`
+	Restrictions []contextDataSourceRestrictionModel `tfsdk:"restrictions"`
+}
+
+type contextDataSourceRestrictionModel struct {
+	ID    types.String `tfsdk:"id"`
+	Type  types.String `tfsdk:"type"`
+	Value types.String `tfsdk:"value"`
+	Name  types.String `tfsdk:"name"`
+}
+
+func newContextDataSource() datasource.DataSource {
+	return &contextDataSource{}
+}
 
-		Schema: map[string]*schema.Schema{
-			"name": {
-				Type:        schema.TypeString,
+func (d *contextDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_context"
+}
+
+func (d *contextDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The ID (UUID) of the context",
+			},
+			"name": schema.StringAttribute{
 				Required:    true,
 				Description: "The name of the context",
 			},
-			"organization": {
-				Type:        schema.TypeString,
+			"organization": schema.StringAttribute{
 				Optional:    true,
-				ForceNew:    true,
 				Description: "The organization where the context is defined",
 			},
+			"restrictions": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "The restrictions of the context",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed: true,
+						},
+						"type": schema.StringAttribute{
+							Computed: true,
+						},
+						"value": schema.StringAttribute{
+							Computed: true,
+						},
+						"name": schema.StringAttribute{
+							Computed: true,
+						},
+					},
+				},
+			},
 		},
 	}
 }
 
-func dataSourceCircleCIContextRead(d *schema.ResourceData, m interface{}) error {
-	c := m.(*client.Client)
+func (d *contextDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	c, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected provider data", fmt.Sprintf("expected *client.Client, got %T", req.ProviderData))
+		return
+	}
 
-	org := d.Get("organization").(string)
-	name := d.Get("name").(string)
+	d.client = c
+}
 
-	ctx, err := c.GetContextByName(name, org)
+func (d *contextDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config contextDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	found, err := d.client.GetContextByName(config.Name.ValueString(), config.Organization.ValueString())
 	if err != nil {
-		return err
+		resp.Diagnostics.AddError("Failed to get context", organizationAccessError(d.client, err, config.Organization.ValueString()).Error())
+		return
+	}
+
+	// Contexts have no restrictions on CircleCI server installations which do not support them
+	restrictions, err := d.client.ListContextRestrictions(found.ID)
+	if err != nil && !errors.Is(err, client.ErrUnsupported) {
+		resp.Diagnostics.AddError("Failed to get context restrictions", organizationAccessError(d.client, err, config.Organization.ValueString()).Error())
+		return
+	}
+
+	config.ID = types.StringValue(found.ID)
+	config.Restrictions = make([]contextDataSourceRestrictionModel, 0, len(restrictions))
+	for _, restriction := range restrictions {
+		config.Restrictions = append(config.Restrictions, contextDataSourceRestrictionModel{
+			ID:    types.StringValue(restriction.ID),
+			Type:  types.StringValue(restriction.Type),
+			Value: types.StringValue(restriction.Value),
+			Name:  types.StringValue(restriction.Name),
+		})
 	}
 
-	d.SetId(ctx.ID)
-	return nil
+	resp.Diagnostics.Append(resp.State.Set(ctx, &config)...)
 }
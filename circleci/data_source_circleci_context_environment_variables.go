@@ -0,0 +1,94 @@
+package circleci
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	client "github.com/mrolla/terraform-provider-circleci/circleci/client"
+)
+
+func dataSourceCircleCIContextEnvironmentVariables() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceCircleCIContextEnvironmentVariablesRead,
+
+		Schema: map[string]*schema.Schema{
+			"context_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"context_id", "context_name"},
+				Description:  "The ID of the context",
+			},
+			"context_name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{"context_id", "context_name"},
+				Description:  "The name of the context",
+			},
+			"organization": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The organization where the context is defined",
+			},
+			"variables": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The environment variables of the context",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"variable": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"created_at": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"updated_at": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceCircleCIContextEnvironmentVariablesRead(d *schema.ResourceData, m interface{}) error {
+	c := m.(*client.Client)
+
+	id := d.Get("context_id").(string)
+	if name, ok := d.GetOk("context_name"); ok {
+		ctx, err := c.GetContextByName(name.(string), d.Get("organization").(string))
+		if err != nil {
+			return err
+		}
+
+		id = ctx.ID
+	}
+
+	envs, err := c.ListContextEnvironmentVariables(id)
+	if err != nil {
+		return fmt.Errorf("failed to get context environment variables: %w", err)
+	}
+
+	variables := make([]interface{}, 0, len(*envs))
+	for _, env := range *envs {
+		variables = append(variables, map[string]interface{}{
+			"variable":   env.Variable,
+			"created_at": env.CreatedAt.Format(time.RFC3339),
+			"updated_at": env.UpdatedAt.Format(time.RFC3339),
+		})
+	}
+
+	if err := d.Set("variables", variables); err != nil {
+		return err
+	}
+
+	_ = d.Set("context_id", id)
+	d.SetId(id)
+	return nil
+}
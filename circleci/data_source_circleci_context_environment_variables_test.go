@@ -0,0 +1,49 @@
+package circleci
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccCircleCIContextEnvironmentVariablesDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccOrgProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCircleCIContextEnvironmentVariablesDataSource,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.circleci_context_environment_variables.by_id", "variables.#", "1"),
+					resource.TestCheckResourceAttr("data.circleci_context_environment_variables.by_id", "variables.0.variable", "VAR"),
+					resource.TestCheckResourceAttrSet("data.circleci_context_environment_variables.by_id", "variables.0.created_at"),
+					resource.TestCheckResourceAttrSet("data.circleci_context_environment_variables.by_id", "variables.0.updated_at"),
+					resource.TestCheckResourceAttrPair("data.circleci_context_environment_variables.by_name", "context_id", "circleci_context.foo", "id"),
+					resource.TestCheckResourceAttr("data.circleci_context_environment_variables.by_name", "variables.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+const testAccCircleCIContextEnvironmentVariablesDataSource = `
+resource "circleci_context" "foo" {
+  name = "terraform-test"
+}
+
+resource "circleci_context_environment_variable" "foo" {
+  variable   = "VAR"
+  value      = "secret-value"
+  context_id = circleci_context.foo.id
+}
+
+data "circleci_context_environment_variables" "by_id" {
+  context_id = circleci_context_environment_variable.foo.context_id
+}
+
+data "circleci_context_environment_variables" "by_name" {
+  context_name = circleci_context.foo.name
+
+  depends_on = [circleci_context_environment_variable.foo]
+}
+`
@@ -3,18 +3,19 @@ package circleci
 import (
 	"testing"
 
-	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 )
 
 func TestAccCircleCIContextDataSource(t *testing.T) {
 	resource.Test(t, resource.TestCase{
-		PreCheck:  func() { testAccPreCheck(t) },
-		Providers: testAccOrgProviders,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccOrgProviders,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccCircleCIContextDataSource,
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttr("data.circleci_context.foo", "name", "terraform-test"),
+					resource.TestCheckResourceAttr("data.circleci_context.foo", "restrictions.#", "0"),
 				),
 			},
 		},
@@ -0,0 +1,103 @@
+package circleci
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	client "github.com/mrolla/terraform-provider-circleci/circleci/client"
+)
+
+func dataSourceCircleCIContexts() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceCircleCIContextsRead,
+
+		Schema: map[string]*schema.Schema{
+			"organization": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The organization where the contexts are defined",
+			},
+			"name_prefix": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only list contexts whose name starts with this prefix",
+			},
+			"name_regex": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Only list contexts whose name matches this regular expression",
+				ValidateFunc: validateRegexpFunc,
+			},
+			"contexts": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The contexts of the organization",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"created_at": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceCircleCIContextsRead(d *schema.ResourceData, m interface{}) error {
+	c := m.(*client.Client)
+
+	organization, err := c.Organization(d.Get("organization").(string))
+	if err != nil {
+		return err
+	}
+
+	prefix := d.Get("name_prefix").(string)
+
+	var nameRegex *regexp.Regexp
+	if v, ok := d.GetOk("name_regex"); ok {
+		nameRegex = regexp.MustCompile(v.(string))
+	}
+
+	contexts, err := c.ListContexts(organization)
+	if err != nil {
+		return fmt.Errorf("failed to list contexts: %w", err)
+	}
+
+	result := make([]interface{}, 0, len(*contexts))
+	for _, ctx := range *contexts {
+		if !strings.HasPrefix(ctx.Name, prefix) {
+			continue
+		}
+
+		if nameRegex != nil && !nameRegex.MatchString(ctx.Name) {
+			continue
+		}
+
+		result = append(result, map[string]interface{}{
+			"id":         ctx.ID,
+			"name":       ctx.Name,
+			"created_at": ctx.CreatedAt.Format(time.RFC3339),
+		})
+	}
+
+	if err := d.Set("contexts", result); err != nil {
+		return err
+	}
+
+	d.SetId(organization)
+	return nil
+}
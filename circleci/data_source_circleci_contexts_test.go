@@ -0,0 +1,48 @@
+package circleci
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccCircleCIContextsDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccOrgProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCircleCIContextsDataSource,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.circleci_contexts.prefix", "contexts.#", "2"),
+					resource.TestCheckResourceAttr("data.circleci_contexts.regex", "contexts.#", "1"),
+					resource.TestCheckResourceAttrPair("data.circleci_contexts.regex", "contexts.0.id", "circleci_context.bar", "id"),
+					resource.TestCheckResourceAttr("data.circleci_contexts.regex", "contexts.0.name", "terraform-test-bar"),
+					resource.TestCheckResourceAttrSet("data.circleci_contexts.regex", "contexts.0.created_at"),
+				),
+			},
+		},
+	})
+}
+
+const testAccCircleCIContextsDataSource = `
+resource "circleci_context" "foo" {
+  name = "terraform-test-foo"
+}
+
+resource "circleci_context" "bar" {
+  name = "terraform-test-bar"
+}
+
+data "circleci_contexts" "prefix" {
+  name_prefix = "terraform-test-"
+
+  depends_on = [circleci_context.foo, circleci_context.bar]
+}
+
+data "circleci_contexts" "regex" {
+  name_regex = "^terraform-test-ba"
+
+  depends_on = [circleci_context.foo, circleci_context.bar]
+}
+`
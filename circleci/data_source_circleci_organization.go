@@ -0,0 +1,111 @@
+package circleci
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	client "github.com/mrolla/terraform-provider-circleci/circleci/client"
+)
+
+var _ datasource.DataSourceWithConfigure = &organizationDataSource{}
+
+type organizationDataSource struct {
+	client *client.Client
+}
+
+type organizationDataSourceModel struct {
+	ID        types.String `tfsdk:"id"`
+	Name      types.String `tfsdk:"name"`
+	Slug      types.String `tfsdk:"slug"`
+	VCSType   types.String `tfsdk:"vcs_type"`
+	AvatarURL types.String `tfsdk:"avatar_url"`
+}
+
+func newOrganizationDataSource() datasource.DataSource {
+	return &organizationDataSource{}
+}
+
+func (d *organizationDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_organization"
+}
+
+func (d *organizationDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The ID (UUID) of the organization",
+			},
+			"name": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "The name of the organization, defaults to the organization of the provider",
+			},
+			"slug": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "The slug of the organization, such as gh/org or circleci/<organization-id>",
+			},
+			"vcs_type": schema.StringAttribute{
+				Computed:    true,
+				Description: "The VCS type of the organization",
+			},
+			"avatar_url": schema.StringAttribute{
+				Computed:    true,
+				Description: "The URL of the avatar of the organization",
+			},
+		},
+	}
+}
+
+func (d *organizationDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	c, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected provider data", fmt.Sprintf("expected *client.Client, got %T", req.ProviderData))
+		return
+	}
+
+	d.client = c
+}
+
+func (d *organizationDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config organizationDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !config.Name.IsNull() && !config.Slug.IsNull() {
+		resp.Diagnostics.AddAttributeError(path.Root("slug"), "Conflicting name and slug", "Only one of name and slug can be set")
+		return
+	}
+
+	// Both are null when the organization of the provider is used
+	org := config.Slug.ValueString()
+	if !config.Name.IsNull() {
+		org = config.Name.ValueString()
+	}
+
+	found, err := d.client.GetOrganization(org)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to get organization", organizationAccessError(d.client, err, org).Error())
+		return
+	}
+
+	config.ID = types.StringValue(found.ID)
+	config.Name = types.StringValue(found.Name)
+	config.Slug = types.StringValue(found.Slug)
+	config.VCSType = types.StringValue(found.VCSType)
+	config.AvatarURL = types.StringValue(found.AvatarURL)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &config)...)
+}
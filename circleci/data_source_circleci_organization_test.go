@@ -0,0 +1,37 @@
+package circleci
+
+import (
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccCircleCIOrganizationDataSource(t *testing.T) {
+	organization := os.Getenv("TEST_CIRCLECI_ORGANIZATION")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccOrgProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCircleCIOrganizationDataSource,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.circleci_organization.default", "name", organization),
+					resource.TestCheckResourceAttrSet("data.circleci_organization.default", "id"),
+					resource.TestCheckResourceAttrSet("data.circleci_organization.default", "slug"),
+					resource.TestCheckResourceAttrPair("data.circleci_organization.by_slug", "id", "data.circleci_organization.default", "id"),
+					resource.TestCheckResourceAttrPair("data.circleci_organization.by_slug", "vcs_type", "data.circleci_organization.default", "vcs_type"),
+				),
+			},
+		},
+	})
+}
+
+const testAccCircleCIOrganizationDataSource = `
+data "circleci_organization" "default" {}
+
+data "circleci_organization" "by_slug" {
+  slug = data.circleci_organization.default.slug
+}
+`
@@ -0,0 +1,100 @@
+package circleci
+
+import (
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	client "github.com/mrolla/terraform-provider-circleci/circleci/client"
+)
+
+func dataSourceCircleCIWebhooks() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceCircleCIWebhooksRead,
+
+		Schema: map[string]*schema.Schema{
+			"project_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The ID (UUID) of the project the webhooks receive events for",
+			},
+			"webhooks": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The webhooks of the project",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"url": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"events": {
+							Type:     schema.TypeSet,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"verify_tls": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"created_at": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"updated_at": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceCircleCIWebhooksRead(d *schema.ResourceData, m interface{}) error {
+	c := m.(*client.Client)
+
+	projectID := d.Get("project_id").(string)
+
+	webhooks, err := c.ListWebhooks(projectID)
+	if err != nil {
+		return err
+	}
+
+	result := make([]interface{}, 0, len(webhooks))
+	for _, webhook := range webhooks {
+		w := map[string]interface{}{
+			"id":         webhook.ID,
+			"name":       webhook.Name,
+			"url":        webhook.URL,
+			"events":     webhook.Events,
+			"verify_tls": webhook.VerifyTLS,
+		}
+
+		if webhook.CreatedAt != nil {
+			w["created_at"] = webhook.CreatedAt.Format(time.RFC3339)
+		}
+
+		if webhook.UpdatedAt != nil {
+			w["updated_at"] = webhook.UpdatedAt.Format(time.RFC3339)
+		}
+
+		result = append(result, w)
+	}
+
+	if err := d.Set("webhooks", result); err != nil {
+		return err
+	}
+
+	d.SetId(projectID)
+	return nil
+}
@@ -0,0 +1,111 @@
+package circleci
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/mrolla/terraform-provider-circleci/circleci/client"
+	"github.com/mrolla/terraform-provider-circleci/circleci/client/rest"
+)
+
+// accessError explains a request the API token was not allowed to make, naming what it tried to
+// access, e.g. "organization foo". Other errors are returned unchanged.
+func accessError(err error, target string) error {
+	switch {
+	case errors.Is(err, rest.ErrUnauthorized):
+		return fmt.Errorf("the API token was rejected while accessing %s, check that api_token (or CIRCLECI_TOKEN) is a valid personal API token: %w", target, err)
+	case errors.Is(err, rest.ErrForbidden):
+		return fmt.Errorf("the API token lacks access to %s, check that its user is a member of the organization: %w", target, err)
+	}
+
+	return err
+}
+
+// withAccessErrors makes the operations of a resource or data source explain the requests the API
+// token was not allowed to make, naming the project, context or organization of the resource.
+func withAccessErrors(r *schema.Resource) *schema.Resource {
+	r.Create = accessErrorFunc(r, r.Create)
+	r.Read = accessErrorFunc(r, r.Read)
+	r.Update = accessErrorFunc(r, r.Update)
+	r.Delete = accessErrorFunc(r, r.Delete)
+
+	if r.Importer != nil && r.Importer.State != nil {
+		importer := r.Importer.State
+		r.Importer.State = func(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+			imported, err := importer(d, m)
+			if err != nil {
+				return nil, accessError(err, accessTarget(r, d, m))
+			}
+
+			return imported, nil
+		}
+	}
+
+	return r
+}
+
+func accessErrorFunc[F ~func(*schema.ResourceData, interface{}) error](r *schema.Resource, f F) F {
+	if f == nil {
+		return nil
+	}
+
+	return func(d *schema.ResourceData, m interface{}) error {
+		err := f(d, m)
+		if err != nil {
+			return accessError(err, accessTarget(r, d, m))
+		}
+
+		return nil
+	}
+}
+
+// accessTarget describes what the operations of a resource access, from its project, context or organization
+func accessTarget(r *schema.Resource, d *schema.ResourceData, m interface{}) string {
+	get := func(key string) string {
+		if _, ok := r.Schema[key]; !ok {
+			return ""
+		}
+
+		value, _ := d.Get(key).(string)
+		return value
+	}
+
+	var organization string
+	if c, ok := m.(*client.Client); ok {
+		organization, _ = c.Organization(get("organization"))
+	}
+
+	for _, key := range []string{"project", "project_id"} {
+		project := get(key)
+		switch {
+		case project == "":
+			continue
+		case organization == "" || client.IsProjectSlug(project):
+			return fmt.Sprintf("project %s", project)
+		default:
+			return fmt.Sprintf("project %s of organization %s", project, organization)
+		}
+	}
+
+	if context := get("context_id"); context != "" {
+		return fmt.Sprintf("context %s", context)
+	}
+
+	if organization != "" {
+		return fmt.Sprintf("organization %s", organization)
+	}
+
+	return "the requested resource"
+}
+
+// organizationAccessError is accessError for an organization, which defaults to the organization of the provider
+func organizationAccessError(c *client.Client, err error, org string) error {
+	organization, orgErr := c.Organization(org)
+	if orgErr != nil {
+		return accessError(err, "the organization")
+	}
+
+	return accessError(err, fmt.Sprintf("organization %s", organization))
+}
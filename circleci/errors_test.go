@@ -0,0 +1,69 @@
+package circleci
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mrolla/terraform-provider-circleci/circleci/client"
+	"github.com/mrolla/terraform-provider-circleci/circleci/client/rest"
+)
+
+func TestAccessError(t *testing.T) {
+	cases := []struct {
+		name     string
+		err      error
+		expected string
+	}{
+		{"unauthorized", &rest.HTTPError{Code: http.StatusUnauthorized, Message: "Invalid token provided."}, "the API token was rejected while accessing organization org, check that api_token (or CIRCLECI_TOKEN) is a valid personal API token: Invalid token provided."},
+		{"forbidden", &rest.HTTPError{Code: http.StatusForbidden, Message: "Permission denied"}, "the API token lacks access to organization org, check that its user is a member of the organization: Permission denied"},
+		{"not found", &rest.HTTPError{Code: http.StatusNotFound, Message: "Not found"}, "Not found"},
+		{"other", errors.New("failure"), "failure"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := accessError(tc.err, "organization org")
+			assert.EqualError(t, err, tc.expected)
+			assert.ErrorIs(t, err, tc.err)
+		})
+	}
+}
+
+func TestAccessErrorResources(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"message": "Permission denied"}`))
+	}))
+	defer server.Close()
+
+	c, err := client.New(client.Config{URL: server.URL + "/api/v2/", Token: "token", VCS: "github", Organization: "default"})
+	assert.NoError(t, err)
+
+	cases := []struct {
+		resource   string
+		attributes map[string]string
+		target     string
+	}{
+		{"circleci_environment_variable", map[string]string{"organization": "org", "project": "project", "name": "NAME"}, "project project of organization org"},
+		{"circleci_environment_variable", map[string]string{"project": "github/org/project", "name": "NAME"}, "project github/org/project"},
+		{"circleci_schedule", map[string]string{"project": "project"}, "project project of organization default"},
+		{"circleci_context_restriction", map[string]string{"context_id": "context"}, "context context"},
+		{"circleci_project", map[string]string{"organization": "org", "name": "project"}, "organization org"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.target, func(t *testing.T) {
+			r := Provider().ResourcesMap[tc.resource]
+			d := r.Data(&terraform.InstanceState{ID: "id", Attributes: tc.attributes})
+
+			err := r.Read(d, c)
+			assert.ErrorIs(t, err, rest.ErrForbidden)
+			assert.ErrorContains(t, err, "the API token lacks access to "+tc.target+",")
+		})
+	}
+}
@@ -1,26 +1,36 @@
 package circleci
 
 import (
-	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
-	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"errors"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 
 	"github.com/mrolla/terraform-provider-circleci/circleci/client"
+	"github.com/mrolla/terraform-provider-circleci/circleci/client/rest"
 )
 
-func Provider() terraform.ResourceProvider {
-	return &schema.Provider{
+// Provider returns the provider built with the plugin SDK. Resources and data sources which have
+// been ported to the plugin framework are served by NewFrameworkProvider instead.
+func Provider() *schema.Provider {
+	p := &schema.Provider{
 		Schema: map[string]*schema.Schema{
 			"api_token": {
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
+				Sensitive:   true,
 				DefaultFunc: schema.EnvDefaultFunc("CIRCLECI_TOKEN", nil),
 				Description: "The token key for API operations.",
 			},
 			"vcs_type": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				DefaultFunc: schema.EnvDefaultFunc("CIRCLECI_VCS_TYPE", "github"),
-				Description: "The VCS type for the organization.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("CIRCLECI_VCS_TYPE", "github"),
+				Description:  "The VCS type for the organization.",
+				ValidateFunc: validation.StringInSlice(client.VCSTypes, false),
 			},
 			"organization": {
 				Type:        schema.TypeString,
@@ -34,24 +44,186 @@ func Provider() terraform.ResourceProvider {
 				DefaultFunc: schema.EnvDefaultFunc("CIRCLECI_URL", "https://circleci.com/api/v2/"),
 				Description: "The URL of the Circle CI API (v2)",
 			},
+			"host": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				DefaultFunc:   schema.EnvDefaultFunc("CIRCLECI_HOST", nil),
+				ConflictsWith: []string{"url"},
+				Description:   "The URL of a CircleCI server installation, instead of CircleCI cloud",
+			},
+			"rest_endpoint": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				RequiredWith: []string{"host"},
+				Description:  "The path of the REST API (v2) of the CircleCI server installation",
+			},
+			"graphql_endpoint": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				RequiredWith: []string{"host"},
+				Description:  "The path of the GraphQL API of the CircleCI server installation",
+			},
+			"value_hash_key": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				DefaultFunc: schema.EnvDefaultFunc("CIRCLECI_VALUE_HASH_KEY", nil),
+				Description: "The key used to store the values of environment variables as HMACs instead of plain SHA-256 hashes",
+			},
+			"max_retries": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      3,
+				Description:  "The number of times a rate limited or failed request is retried",
+				ValidateFunc: validation.IntBetween(0, 10),
+			},
+			"request_timeout": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      10,
+				Description:  "The timeout of each attempt of a request, in seconds",
+				ValidateFunc: validation.IntBetween(1, 3600),
+			},
+			"max_requests_per_second": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				Description:  "The maximum number of requests sent per second, or 0 for no limit",
+				ValidateFunc: validation.IntBetween(0, 1000),
+			},
+			"ca_cert_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The path to a PEM file of certificate authorities to trust in addition to the system ones",
+			},
+			"insecure_skip_verify": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to skip verifying the TLS certificate of the API",
+			},
+			"client_cert": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				RequiredWith: []string{"client_key"},
+				Description:  "The path to a PEM client certificate for mutual TLS",
+			},
+			"client_key": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				RequiredWith: []string{"client_cert"},
+				Description:  "The path to the PEM private key of the client certificate",
+			},
+			"proxy_url": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The URL of the proxy to send requests through, instead of the one from the environment",
+			},
 		},
 		ResourcesMap: map[string]*schema.Resource{
-			"circleci_environment_variable":         resourceCircleCIEnvironmentVariable(),
-			"circleci_context":                      resourceCircleCIContext(),
-			"circleci_context_environment_variable": resourceCircleCIContextEnvironmentVariable(),
+			"circleci_environment_variable":          resourceCircleCIEnvironmentVariable(),
+			"circleci_context_environment_variable":  resourceCircleCIContextEnvironmentVariable(),
+			"circleci_context_environment_variables": resourceCircleCIContextEnvironmentVariables(),
+			"circleci_context_restriction":           resourceCircleCIContextRestriction(),
+			"circleci_project":                       resourceCircleCIProject(),
+			"circleci_project_environment_variables": resourceCircleCIProjectEnvironmentVariables(),
+			"circleci_project_settings":              resourceCircleCIProjectSettings(),
+			"circleci_checkout_key":                  resourceCircleCICheckoutKey(),
+			"circleci_ssh_key":                       resourceCircleCISSHKey(),
+			"circleci_schedule":                      resourceCircleCISchedule(),
+			"circleci_webhook":                       resourceCircleCIWebhook(),
 		},
 		DataSourcesMap: map[string]*schema.Resource{
-			"circleci_context": dataSourceCircleCIContext(),
+			"circleci_contexts":                      dataSourceCircleCIContexts(),
+			"circleci_context_environment_variables": dataSourceCircleCIContextEnvironmentVariables(),
+			"circleci_webhooks":                      dataSourceCircleCIWebhooks(),
 		},
-		ConfigureFunc: providerConfigure,
+		ConfigureFunc: providerConfigure(&rateLimiters{}),
+	}
+
+	for _, r := range p.ResourcesMap {
+		withAccessErrors(r)
 	}
+	for _, r := range p.DataSourcesMap {
+		withAccessErrors(r)
+	}
+
+	return p
 }
 
-func providerConfigure(d *schema.ResourceData) (interface{}, error) {
-	return client.New(client.Config{
-		URL:          d.Get("url").(string),
-		Token:        d.Get("api_token").(string),
-		Organization: d.Get("organization").(string),
-		VCS:          d.Get("vcs_type").(string),
-	})
+// providerConfigure returns the function creating the client of the SDK provider. The limiters are
+// shared with the framework provider served alongside it.
+func providerConfigure(limiters *rateLimiters) schema.ConfigureFunc {
+	return func(d *schema.ResourceData) (interface{}, error) {
+		// The token is optional in the schema, which has to match the schema of the framework provider
+		if d.Get("api_token").(string) == "" {
+			return nil, errors.New("api_token is required")
+		}
+
+		return client.New(client.Config{
+			URL:          d.Get("url").(string),
+			Token:        d.Get("api_token").(string),
+			Organization: d.Get("organization").(string),
+			VCS:          d.Get("vcs_type").(string),
+
+			Host:            d.Get("host").(string),
+			RESTEndpoint:    stringOrEnv(d.Get("rest_endpoint").(string), "CIRCLECI_REST_ENDPOINT", "api/v2"),
+			GraphQLEndpoint: stringOrEnv(d.Get("graphql_endpoint").(string), "CIRCLECI_GRAPHQL_ENDPOINT", "graphql-unstable"),
+
+			MaxRetries:     d.Get("max_retries").(int),
+			RequestTimeout: time.Duration(d.Get("request_timeout").(int)) * time.Second,
+			RateLimiter:    limiters.get(d.Get("max_requests_per_second").(int)),
+
+			CACertFile:         d.Get("ca_cert_file").(string),
+			InsecureSkipVerify: d.Get("insecure_skip_verify").(bool),
+			ClientCertFile:     d.Get("client_cert").(string),
+			ClientKeyFile:      d.Get("client_key").(string),
+			ProxyURL:           d.Get("proxy_url").(string),
+
+			ValueHashKey: d.Get("value_hash_key").(string),
+		})
+	}
+}
+
+// stringOrEnv returns the configured value, falling back to an environment variable and then to a
+// default value. It replaces schema.EnvDefaultFunc for settings with RequiredWith, since the SDK
+// considers defaulted values as set but only finds the attributes they require in the configuration.
+func stringOrEnv(value, env, def string) string {
+	if value != "" {
+		return value
+	}
+
+	if v := os.Getenv(env); v != "" {
+		return v
+	}
+
+	return def
+}
+
+// rateLimiters holds the rate limiters of one provider server. Its SDK and framework providers are
+// configured separately, so they share limiters through it, while other provider configurations such
+// as aliased providers have their own.
+type rateLimiters struct {
+	mu       sync.Mutex
+	limiters map[int]*rest.RateLimiter
+}
+
+// get returns the rate limiter for a number of requests per second, or nil for no limit
+func (l *rateLimiters) get(requestsPerSecond int) *rest.RateLimiter {
+	if requestsPerSecond == 0 {
+		return nil
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.limiters == nil {
+		l.limiters = map[int]*rest.RateLimiter{}
+	}
+
+	if _, ok := l.limiters[requestsPerSecond]; !ok {
+		l.limiters[requestsPerSecond] = rest.NewRateLimiter(requestsPerSecond)
+	}
+
+	return l.limiters[requestsPerSecond]
 }
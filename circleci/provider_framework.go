@@ -0,0 +1,286 @@
+package circleci
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-mux/tf5to6server"
+	"github.com/hashicorp/terraform-plugin-mux/tf6muxserver"
+	sdkschema "github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/mrolla/terraform-provider-circleci/circleci/client"
+)
+
+// frameworkProvider serves the resources and data sources built with the plugin framework.
+// It is muxed with the SDK provider, so its schema has to match the schema of Provider.
+type frameworkProvider struct {
+	// organizationEnv is the environment variable holding the default organization
+	organizationEnv string
+
+	// rateLimiters are shared with the SDK provider served alongside it
+	rateLimiters *rateLimiters
+}
+
+type frameworkProviderModel struct {
+	APIToken        types.String `tfsdk:"api_token"`
+	VCSType         types.String `tfsdk:"vcs_type"`
+	Organization    types.String `tfsdk:"organization"`
+	URL             types.String `tfsdk:"url"`
+	Host            types.String `tfsdk:"host"`
+	RESTEndpoint    types.String `tfsdk:"rest_endpoint"`
+	GraphQLEndpoint types.String `tfsdk:"graphql_endpoint"`
+	ValueHashKey    types.String `tfsdk:"value_hash_key"`
+	MaxRetries      types.Int64  `tfsdk:"max_retries"`
+	RequestTimeout  types.Int64  `tfsdk:"request_timeout"`
+	MaxRequests     types.Int64  `tfsdk:"max_requests_per_second"`
+
+	CACertFile         types.String `tfsdk:"ca_cert_file"`
+	InsecureSkipVerify types.Bool   `tfsdk:"insecure_skip_verify"`
+	ClientCert         types.String `tfsdk:"client_cert"`
+	ClientKey          types.String `tfsdk:"client_key"`
+	ProxyURL           types.String `tfsdk:"proxy_url"`
+}
+
+// NewFrameworkProvider returns the provider built with the plugin framework
+func NewFrameworkProvider() provider.Provider {
+	return &frameworkProvider{
+		organizationEnv: "CIRCLECI_ORGANIZATION",
+		rateLimiters:    &rateLimiters{},
+	}
+}
+
+// ProtoV6ProviderServerFactory muxes the SDK provider, upgraded to protocol version 6, with the framework provider
+func ProtoV6ProviderServerFactory(ctx context.Context) (func() tfprotov6.ProviderServer, error) {
+	return protoV6ProviderServerFactory(ctx, Provider(), NewFrameworkProvider().(*frameworkProvider))
+}
+
+func protoV6ProviderServerFactory(ctx context.Context, sdkProvider *sdkschema.Provider, frameworkProvider *frameworkProvider) (func() tfprotov6.ProviderServer, error) {
+	// Both providers are configured with the same settings, so they share one rate limiter
+	limiters := &rateLimiters{}
+	sdkProvider.ConfigureFunc = providerConfigure(limiters)
+	frameworkProvider.rateLimiters = limiters
+
+	upgraded, err := tf5to6server.UpgradeServer(ctx, sdkProvider.GRPCProvider)
+	if err != nil {
+		return nil, err
+	}
+
+	mux, err := tf6muxserver.NewMuxServer(
+		ctx,
+		providerserver.NewProtocol6(frameworkProvider),
+		func() tfprotov6.ProviderServer { return upgraded },
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return mux.ProviderServer, nil
+}
+
+func (p *frameworkProvider) Metadata(_ context.Context, _ provider.MetadataRequest, resp *provider.MetadataResponse) {
+	resp.TypeName = "circleci"
+}
+
+func (p *frameworkProvider) Schema(_ context.Context, _ provider.SchemaRequest, resp *provider.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"api_token": schema.StringAttribute{
+				Optional:    true,
+				Sensitive:   true,
+				Description: "The token key for API operations.",
+			},
+			"vcs_type": schema.StringAttribute{
+				Optional:    true,
+				Description: "The VCS type for the organization.",
+			},
+			"organization": schema.StringAttribute{
+				Optional:    true,
+				Description: "The CircleCI organization.",
+			},
+			"url": schema.StringAttribute{
+				Optional:    true,
+				Description: "The URL of the Circle CI API (v2)",
+			},
+			"host": schema.StringAttribute{
+				Optional:    true,
+				Description: "The URL of a CircleCI server installation, instead of CircleCI cloud",
+			},
+			"rest_endpoint": schema.StringAttribute{
+				Optional:    true,
+				Description: "The path of the REST API (v2) of the CircleCI server installation",
+			},
+			"graphql_endpoint": schema.StringAttribute{
+				Optional:    true,
+				Description: "The path of the GraphQL API of the CircleCI server installation",
+			},
+			"value_hash_key": schema.StringAttribute{
+				Optional:    true,
+				Sensitive:   true,
+				Description: "The key used to store the values of environment variables as HMACs instead of plain SHA-256 hashes",
+			},
+			"max_retries": schema.Int64Attribute{
+				Optional:    true,
+				Description: "The number of times a rate limited or failed request is retried",
+			},
+			"request_timeout": schema.Int64Attribute{
+				Optional:    true,
+				Description: "The timeout of each attempt of a request, in seconds",
+			},
+			"max_requests_per_second": schema.Int64Attribute{
+				Optional:    true,
+				Description: "The maximum number of requests sent per second, or 0 for no limit",
+			},
+			"ca_cert_file": schema.StringAttribute{
+				Optional:    true,
+				Description: "The path to a PEM file of certificate authorities to trust in addition to the system ones",
+			},
+			"insecure_skip_verify": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Whether to skip verifying the TLS certificate of the API",
+			},
+			"client_cert": schema.StringAttribute{
+				Optional:    true,
+				Description: "The path to a PEM client certificate for mutual TLS",
+			},
+			"client_key": schema.StringAttribute{
+				Optional:    true,
+				Description: "The path to the PEM private key of the client certificate",
+			},
+			"proxy_url": schema.StringAttribute{
+				Optional:    true,
+				Description: "The URL of the proxy to send requests through, instead of the one from the environment",
+			},
+		},
+	}
+}
+
+func (p *frameworkProvider) Configure(ctx context.Context, req provider.ConfigureRequest, resp *provider.ConfigureResponse) {
+	var config frameworkProviderModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	token := stringValueOrEnv(config.APIToken, "CIRCLECI_TOKEN", "")
+	if token == "" {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("api_token"),
+			"Missing API token",
+			"api_token is required. It can also be set with the CIRCLECI_TOKEN environment variable.",
+		)
+		return
+	}
+
+	if !config.Host.IsNull() && !config.URL.IsNull() {
+		resp.Diagnostics.AddAttributeError(path.Root("host"), "Conflicting host and url", "host selects a CircleCI server installation and cannot be set with url")
+	}
+
+	if !config.RESTEndpoint.IsNull() && config.Host.IsNull() {
+		resp.Diagnostics.AddAttributeError(path.Root("rest_endpoint"), "Missing host", "rest_endpoint requires host")
+	}
+
+	if !config.GraphQLEndpoint.IsNull() && config.Host.IsNull() {
+		resp.Diagnostics.AddAttributeError(path.Root("graphql_endpoint"), "Missing host", "graphql_endpoint requires host")
+	}
+
+	vcs := stringValueOrEnv(config.VCSType, "CIRCLECI_VCS_TYPE", "github")
+	if !slices.Contains(client.VCSTypes, vcs) {
+		resp.Diagnostics.AddAttributeError(path.Root("vcs_type"), "Invalid vcs_type", fmt.Sprintf("expected vcs_type to be one of %q, got %s", client.VCSTypes, vcs))
+	}
+
+	maxRetries := int64ValueOrDefault(config.MaxRetries, 3)
+	if maxRetries < 0 || maxRetries > 10 {
+		resp.Diagnostics.AddAttributeError(path.Root("max_retries"), "Invalid max_retries", "max_retries must be between 0 and 10")
+	}
+
+	requestTimeout := int64ValueOrDefault(config.RequestTimeout, 10)
+	if requestTimeout < 1 || requestTimeout > 3600 {
+		resp.Diagnostics.AddAttributeError(path.Root("request_timeout"), "Invalid request_timeout", "request_timeout must be between 1 and 3600")
+	}
+
+	maxRequests := int64ValueOrDefault(config.MaxRequests, 0)
+	if maxRequests < 0 || maxRequests > 1000 {
+		resp.Diagnostics.AddAttributeError(path.Root("max_requests_per_second"), "Invalid max_requests_per_second", "max_requests_per_second must be between 0 and 1000")
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	c, err := client.New(client.Config{
+		URL:          stringValueOrEnv(config.URL, "CIRCLECI_URL", "https://circleci.com/api/v2/"),
+		Token:        token,
+		Organization: stringValueOrEnv(config.Organization, p.organizationEnv, ""),
+		VCS:          vcs,
+
+		Host:            stringValueOrEnv(config.Host, "CIRCLECI_HOST", ""),
+		RESTEndpoint:    stringValueOrEnv(config.RESTEndpoint, "CIRCLECI_REST_ENDPOINT", "api/v2"),
+		GraphQLEndpoint: stringValueOrEnv(config.GraphQLEndpoint, "CIRCLECI_GRAPHQL_ENDPOINT", "graphql-unstable"),
+
+		MaxRetries:     int(maxRetries),
+		RequestTimeout: time.Duration(requestTimeout) * time.Second,
+		RateLimiter:    p.rateLimiters.get(int(maxRequests)),
+
+		CACertFile:         config.CACertFile.ValueString(),
+		InsecureSkipVerify: config.InsecureSkipVerify.ValueBool(),
+		ClientCertFile:     config.ClientCert.ValueString(),
+		ClientKeyFile:      config.ClientKey.ValueString(),
+		ProxyURL:           config.ProxyURL.ValueString(),
+
+		ValueHashKey: stringValueOrEnv(config.ValueHashKey, "CIRCLECI_VALUE_HASH_KEY", ""),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to create CircleCI client", err.Error())
+		return
+	}
+
+	resp.DataSourceData = c
+	resp.ResourceData = c
+}
+
+func (p *frameworkProvider) Resources(_ context.Context) []func() resource.Resource {
+	return []func() resource.Resource{
+		newContextResource,
+	}
+}
+
+func (p *frameworkProvider) DataSources(_ context.Context) []func() datasource.DataSource {
+	return []func() datasource.DataSource{
+		newContextDataSource,
+		newOrganizationDataSource,
+	}
+}
+
+// stringValueOrEnv returns the configured value, falling back to an environment variable and then
+// to a default value, like schema.EnvDefaultFunc does for the SDK provider.
+func stringValueOrEnv(value types.String, env, def string) string {
+	if !value.IsNull() && !value.IsUnknown() {
+		return value.ValueString()
+	}
+
+	if v := os.Getenv(env); v != "" {
+		return v
+	}
+
+	return def
+}
+
+// int64ValueOrDefault returns the configured value, falling back to a default value
+func int64ValueOrDefault(value types.Int64, def int64) int64 {
+	if !value.IsNull() && !value.IsUnknown() {
+		return value.ValueInt64()
+	}
+
+	return def
+}
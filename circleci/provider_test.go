@@ -1,38 +1,180 @@
 package circleci
 
 import (
+	"context"
 	"os"
 	"testing"
 
-	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
-	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 var (
 	testAccNoOrgProvider  *schema.Provider
-	testAccNoOrgProviders map[string]terraform.ResourceProvider
+	testAccNoOrgProviders map[string]func() (tfprotov6.ProviderServer, error)
 )
 
 var (
 	testAccOrgProvider  *schema.Provider
-	testAccOrgProviders map[string]terraform.ResourceProvider
+	testAccOrgProviders map[string]func() (tfprotov6.ProviderServer, error)
 )
 
 func init() {
-	testAccNoOrgProvider = Provider().(*schema.Provider)
-	testAccNoOrgProviders = map[string]terraform.ResourceProvider{
-		"circleci": testAccNoOrgProvider,
-	}
+	testAccNoOrgProvider = Provider()
+	testAccNoOrgProviders = testAccProtoV6ProviderFactories(testAccNoOrgProvider, NewFrameworkProvider().(*frameworkProvider))
 
-	testAccOrgProvider = Provider().(*schema.Provider)
+	testAccOrgProvider = Provider()
 	testAccOrgProvider.Schema["organization"] = &schema.Schema{
 		Type:        schema.TypeString,
 		Optional:    true,
 		DefaultFunc: schema.EnvDefaultFunc("TEST_CIRCLECI_ORGANIZATION", nil),
 		Description: "The CircleCI organization.",
 	}
-	testAccOrgProviders = map[string]terraform.ResourceProvider{
-		"circleci": testAccOrgProvider,
+	testAccOrgProviders = testAccProtoV6ProviderFactories(testAccOrgProvider, &frameworkProvider{
+		organizationEnv: "TEST_CIRCLECI_ORGANIZATION",
+	})
+}
+
+// testAccProtoV6ProviderFactories muxes the given providers, so that the SDK provider can be used
+// to check the remote objects once it has been configured.
+func testAccProtoV6ProviderFactories(sdkProvider *schema.Provider, frameworkProvider *frameworkProvider) map[string]func() (tfprotov6.ProviderServer, error) {
+	return map[string]func() (tfprotov6.ProviderServer, error){
+		"circleci": func() (tfprotov6.ProviderServer, error) {
+			factory, err := protoV6ProviderServerFactory(context.Background(), sdkProvider, frameworkProvider)
+			if err != nil {
+				return nil, err
+			}
+
+			return factory(), nil
+		},
+	}
+}
+
+func TestProviderValidate(t *testing.T) {
+	cases := []struct {
+		name   string
+		config map[string]interface{}
+		errors int
+	}{
+		{"cloud", map[string]interface{}{"api_token": "token", "organization": "org"}, 0},
+		{"server", map[string]interface{}{"api_token": "token", "host": "https://circleci.example.com"}, 0},
+		{"server endpoints", map[string]interface{}{"api_token": "token", "host": "https://circleci.example.com", "rest_endpoint": "api/v2"}, 0},
+		{"endpoint without host", map[string]interface{}{"api_token": "token", "rest_endpoint": "api/v2"}, 1},
+		{"host and url", map[string]interface{}{"api_token": "token", "host": "https://circleci.example.com", "url": "https://circleci.com/api/v2/"}, 1},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			diags := Provider().Validate(terraform.NewResourceConfigRaw(tc.config))
+			if len(diags) != tc.errors {
+				t.Errorf("expected %d errors, got %v", tc.errors, diags)
+			}
+		})
+	}
+}
+
+func TestProviderServer(t *testing.T) {
+	factory, err := ProtoV6ProviderServerFactory(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The mux server rejects providers whose schemas differ, and the framework validates its schemas
+	resp, err := factory().GetProviderSchema(context.Background(), &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, diag := range resp.Diagnostics {
+		t.Errorf("%s: %s", diag.Summary, diag.Detail)
+	}
+
+	for _, name := range []string{"circleci_context", "circleci_environment_variable"} {
+		if _, ok := resp.ResourceSchemas[name]; !ok {
+			t.Errorf("resource %s is not served", name)
+		}
+	}
+}
+
+func TestProviderServerRateLimiters(t *testing.T) {
+	first := NewFrameworkProvider().(*frameworkProvider)
+	if _, err := protoV6ProviderServerFactory(context.Background(), Provider(), first); err != nil {
+		t.Fatal(err)
+	}
+
+	second := NewFrameworkProvider().(*frameworkProvider)
+	if _, err := protoV6ProviderServerFactory(context.Background(), Provider(), second); err != nil {
+		t.Fatal(err)
+	}
+
+	// Each provider server, such as the one of an aliased provider, throttles its own requests
+	if first.rateLimiters.get(5) != first.rateLimiters.get(5) {
+		t.Error("expected the requests of a provider server to share a rate limiter")
+	}
+	if first.rateLimiters.get(5) == second.rateLimiters.get(5) {
+		t.Error("expected provider servers to have their own rate limiters")
+	}
+	if first.rateLimiters.get(0) != nil {
+		t.Error("expected no rate limiter without max_requests_per_second")
+	}
+}
+
+func TestProviderServerContextState(t *testing.T) {
+	factory, err := ProtoV6ProviderServerFactory(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// State written by the SDK implementation of circleci_context, before recreate_on_rename existed
+	resp, err := factory().UpgradeResourceState(context.Background(), &tfprotov6.UpgradeResourceStateRequest{
+		TypeName: "circleci_context",
+		Version:  0,
+		RawState: &tfprotov6.RawState{
+			JSON: []byte(`{"id":"6d87b798-5edb-4d99-b424-ce73b43affb9","name":"build","organization":"hashicorp"}`),
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, diag := range resp.Diagnostics {
+		t.Errorf("%s: %s", diag.Summary, diag.Detail)
+	}
+
+	// recreate_on_rename has its default value, so that the upgraded context plans no update
+	value, err := resp.UpgradedState.Unmarshal(tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"id":                 tftypes.String,
+		"name":               tftypes.String,
+		"organization":       tftypes.String,
+		"recreate_on_rename": tftypes.Bool,
+		"owner_id":           tftypes.String,
+		"created_at":         tftypes.String,
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var attributes map[string]tftypes.Value
+	if err := value.As(&attributes); err != nil {
+		t.Fatal(err)
+	}
+
+	var recreateOnRename *bool
+	if err := attributes["recreate_on_rename"].As(&recreateOnRename); err != nil {
+		t.Fatal(err)
+	}
+	if recreateOnRename == nil || *recreateOnRename {
+		t.Errorf("expected recreate_on_rename to be false, got %v", recreateOnRename)
+	}
+
+	var name string
+	if err := attributes["name"].As(&name); err != nil {
+		t.Fatal(err)
+	}
+	if name != "build" {
+		t.Errorf("expected name to be build, got %q", name)
 	}
 }
 
@@ -0,0 +1,149 @@
+package circleci
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	client "github.com/mrolla/terraform-provider-circleci/circleci/client"
+)
+
+// The API reports user keys as "github-user-key" but expects "user-key" when creating them
+var checkoutKeyCreateTypes = map[string]string{
+	"deploy-key":      "deploy-key",
+	"github-user-key": "user-key",
+}
+
+func resourceCircleCICheckoutKey() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceCircleCICheckoutKeyCreate,
+		Read:   resourceCircleCICheckoutKeyRead,
+		Delete: resourceCircleCICheckoutKeyDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceCircleCICheckoutKeyImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"project": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The name, ID or slug of the CircleCI project to create the checkout key for",
+			},
+			"organization": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The organization where the project is defined",
+			},
+			"type": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "The type of checkout key, either deploy-key or github-user-key",
+				ValidateFunc: validation.StringInSlice([]string{"deploy-key", "github-user-key"}, false),
+			},
+			"fingerprint": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The fingerprint of the checkout key",
+			},
+			"public_key": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The public SSH key of the checkout key",
+			},
+			"preferred": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether this is the preferred checkout key for the project",
+			},
+			"created_at": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The date and time the checkout key was created",
+			},
+		},
+	}
+}
+
+func resourceCircleCICheckoutKeyCreate(d *schema.ResourceData, m interface{}) error {
+	c := m.(*client.Client)
+
+	organization, err := c.ProjectOrganization(d.Get("organization").(string), d.Get("project").(string))
+	if err != nil {
+		return err
+	}
+
+	project := d.Get("project").(string)
+	keyType := checkoutKeyCreateTypes[d.Get("type").(string)]
+
+	key, err := c.CreateCheckoutKey(organization, project, keyType)
+	if err != nil {
+		return fmt.Errorf("error creating checkout key: %w", err)
+	}
+
+	d.SetId(key.Fingerprint)
+	return resourceCircleCICheckoutKeyRead(d, m)
+}
+
+func resourceCircleCICheckoutKeyRead(d *schema.ResourceData, m interface{}) error {
+	c := m.(*client.Client)
+
+	organization, err := c.ProjectOrganization(d.Get("organization").(string), d.Get("project").(string))
+	if err != nil {
+		return err
+	}
+
+	key, err := c.GetCheckoutKey(organization, d.Get("project").(string), d.Id())
+	if err != nil {
+		if errors.Is(err, client.ErrCheckoutKeyNotFound) {
+			d.SetId("")
+			return nil
+		}
+
+		return fmt.Errorf("failed to get checkout key: %w", err)
+	}
+
+	_ = d.Set("organization", organization)
+	_ = d.Set("type", key.Type)
+	_ = d.Set("fingerprint", key.Fingerprint)
+	_ = d.Set("public_key", key.PublicKey)
+	_ = d.Set("preferred", key.Preferred)
+	_ = d.Set("created_at", key.CreatedAt.Format(time.RFC3339))
+
+	return nil
+}
+
+func resourceCircleCICheckoutKeyDelete(d *schema.ResourceData, m interface{}) error {
+	c := m.(*client.Client)
+
+	organization, err := c.ProjectOrganization(d.Get("organization").(string), d.Get("project").(string))
+	if err != nil {
+		return err
+	}
+
+	if err := c.DeleteCheckoutKey(organization, d.Get("project").(string), d.Id()); err != nil {
+		return fmt.Errorf("error deleting checkout key: %w", err)
+	}
+
+	return nil
+}
+
+func resourceCircleCICheckoutKeyImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.SplitN(d.Id(), "/", 3)
+	if len(parts) != 3 {
+		return nil, errors.New("importing checkout keys requires $organization/$project/$fingerprint")
+	}
+
+	_ = d.Set("organization", parts[0])
+	_ = d.Set("project", parts[1])
+	d.SetId(parts[2])
+
+	return []*schema.ResourceData{d}, nil
+}
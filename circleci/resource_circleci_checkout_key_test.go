@@ -0,0 +1,96 @@
+package circleci
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	client "github.com/mrolla/terraform-provider-circleci/circleci/client"
+)
+
+func TestAccCircleCICheckoutKey_basic(t *testing.T) {
+	project := os.Getenv("CIRCLECI_PROJECT")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccOrgProviders,
+		CheckDestroy:             testAccCheckCircleCICheckoutKeyDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCircleCICheckoutKeyConfig(project),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("circleci_checkout_key.foo", "project", project),
+					resource.TestCheckResourceAttr("circleci_checkout_key.foo", "type", "deploy-key"),
+					resource.TestCheckResourceAttrSet("circleci_checkout_key.foo", "fingerprint"),
+					resource.TestCheckResourceAttrSet("circleci_checkout_key.foo", "public_key"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccCircleCICheckoutKey_import(t *testing.T) {
+	project := os.Getenv("CIRCLECI_PROJECT")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccOrgProviders,
+		CheckDestroy:             testAccCheckCircleCICheckoutKeyDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCircleCICheckoutKeyConfig(project),
+			},
+			{
+				ResourceName: "circleci_checkout_key.foo",
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					rs, ok := s.RootModule().Resources["circleci_checkout_key.foo"]
+					if !ok {
+						return "", errors.New("Not found: circleci_checkout_key.foo")
+					}
+
+					return fmt.Sprintf(
+						"%s/%s/%s",
+						rs.Primary.Attributes["organization"],
+						project,
+						rs.Primary.ID,
+					), nil
+				},
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckCircleCICheckoutKeyDestroy(s *terraform.State) error {
+	c := testAccOrgProvider.Meta().(*client.Client)
+
+	for _, resource := range s.RootModule().Resources {
+		if resource.Type != "circleci_checkout_key" {
+			continue
+		}
+
+		if resource.Primary.ID == "" {
+			return fmt.Errorf("No instance ID is set")
+		}
+
+		_, err := c.GetCheckoutKey(resource.Primary.Attributes["organization"], resource.Primary.Attributes["project"], resource.Primary.ID)
+		if err == nil {
+			return fmt.Errorf("Checkout key %s still exists", resource.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+func testAccCircleCICheckoutKeyConfig(project string) string {
+	return fmt.Sprintf(`
+resource "circleci_checkout_key" "foo" {
+  project = "%s"
+  type    = "deploy-key"
+}`, project)
+}
@@ -1,97 +1,297 @@
 package circleci
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
-	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 
 	client "github.com/mrolla/terraform-provider-circleci/circleci/client"
 )
 
-func resourceCircleCIContext() *schema.Resource {
-	return &schema.Resource{
-		Create: resourceCircleCIContextCreate,
-		Read:   resourceCircleCIContextRead,
-		Delete: resourceCircleCIContextDelete,
-		Importer: &schema.ResourceImporter{
-			State: resourceCircleCIContextImport,
-		},
+var (
+	_ resource.ResourceWithConfigure    = &contextResource{}
+	_ resource.ResourceWithImportState  = &contextResource{}
+	_ resource.ResourceWithModifyPlan   = &contextResource{}
+	_ resource.ResourceWithUpgradeState = &contextResource{}
+)
 
-		Schema: map[string]*schema.Schema{
-			"name": {
-				Type:        schema.TypeString,
+type contextResource struct {
+	client *client.Client
+}
+
+type contextResourceModel struct {
+	ID               types.String `tfsdk:"id"`
+	Name             types.String `tfsdk:"name"`
+	Organization     types.String `tfsdk:"organization"`
+	RecreateOnRename types.Bool   `tfsdk:"recreate_on_rename"`
+	OwnerID          types.String `tfsdk:"owner_id"`
+	CreatedAt        types.String `tfsdk:"created_at"`
+}
+
+func newContextResource() resource.Resource {
+	return &contextResource{}
+}
+
+func (r *contextResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_context"
+}
+
+func (r *contextResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// Version 1 sets recreate_on_rename in the state written by the SDK
+		Version: 1,
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The ID (UUID) of the context",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
 				Required:    true,
-				ForceNew:    true,
 				Description: "The name of the context",
 			},
-			"organization": {
-				Type:        schema.TypeString,
+			"organization": schema.StringAttribute{
 				Optional:    true,
-				ForceNew:    true,
+				Computed:    true,
 				Description: "The organization where the context will be created",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"recreate_on_rename": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+				Description: "Whether renaming the context is allowed to delete and recreate it",
+			},
+			"owner_id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The ID (UUID) of the organization owning the context",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"created_at": schema.StringAttribute{
+				Computed:    true,
+				Description: "The date and time the context was created",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 		},
 	}
 }
 
-func resourceCircleCIContextCreate(d *schema.ResourceData, m interface{}) error {
-	c := m.(*client.Client)
+func (r *contextResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	c, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected provider data", fmt.Sprintf("expected *client.Client, got %T", req.ProviderData))
+		return
+	}
 
-	name := d.Get("name").(string)
-	org := d.Get("organization").(string)
+	r.client = c
+}
 
-	ctx, err := c.CreateContext(org, name)
+func (r *contextResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan contextResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	created, err := r.client.CreateContext(plan.Organization.ValueString(), plan.Name.ValueString())
 	if err != nil {
-		return fmt.Errorf("error creating context: %w", err)
+		resp.Diagnostics.AddError("Error creating context", organizationAccessError(r.client, err, plan.Organization.ValueString()).Error())
+		return
 	}
 
-	d.SetId(ctx.ID)
-	return resourceCircleCIContextRead(d, m)
+	plan.ID = types.StringValue(created.ID)
+
+	if _, err := r.read(&plan); err != nil {
+		resp.Diagnostics.AddError("Failed to get context", organizationAccessError(r.client, err, plan.Organization.ValueString()).Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
 
-func resourceCircleCIContextRead(d *schema.ResourceData, m interface{}) error {
-	c := m.(*client.Client)
+func (r *contextResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state contextResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	ctx, err := c.GetContext(d.Id())
+	found, err := r.read(&state)
 	if err != nil {
-		if errors.Is(err, client.ErrContextNotFound) {
-			d.SetId("")
-			return nil
-		}
+		resp.Diagnostics.AddError("Failed to get context", organizationAccessError(r.client, err, state.Organization.ValueString()).Error())
+		return
+	}
 
-		return err
+	if !found {
+		resp.State.RemoveResource(ctx)
+		return
 	}
 
-	_ = d.Set("name", ctx.Name)
-	return nil
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
 
-func resourceCircleCIContextDelete(d *schema.ResourceData, m interface{}) error {
-	c := m.(*client.Client)
+// Only recreate_on_rename can change without recreating the context, and it is not sent to the API
+func (r *contextResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan contextResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	if err := c.DeleteContext(d.Id()); err != nil {
-		return fmt.Errorf("error deleting context: %w", err)
+	if _, err := r.read(&plan); err != nil {
+		resp.Diagnostics.AddError("Failed to get context", organizationAccessError(r.client, err, plan.Organization.ValueString()).Error())
+		return
 	}
 
-	return nil
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
 
-func resourceCircleCIContextImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
-	c := m.(*client.Client)
+func (r *contextResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state contextResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.client.DeleteContext(state.ID.ValueString()); err != nil {
+		resp.Diagnostics.AddError("Error deleting context", organizationAccessError(r.client, err, state.Organization.ValueString()).Error())
+	}
+}
 
-	parts := strings.Split(d.Id(), "/")
+// The API cannot rename contexts. Renaming one means recreating it, which deletes all of its
+// environment variables, so it is only done when explicitly allowed.
+func (r *contextResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var state, plan contextResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.Name.IsUnknown() || plan.Name.Equal(state.Name) {
+		return
+	}
+
+	if !plan.RecreateOnRename.ValueBool() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("name"),
+			"Context cannot be renamed",
+			fmt.Sprintf(
+				"renaming context %q to %q requires recreating it, which deletes all of its environment variables. "+
+					"Set recreate_on_rename = true to allow it", state.Name.ValueString(), plan.Name.ValueString(),
+			),
+		)
+		return
+	}
+
+	resp.RequiresReplace = append(resp.RequiresReplace, path.Root("name"))
+}
+
+func (r *contextResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: {StateUpgrader: upgradeContextStateV0},
+	}
+}
+
+// upgradeContextStateV0 upgrades the state written by the SDK implementation of circleci_context, which
+// has no value for recreate_on_rename. Without one, every context would plan an update to false.
+func upgradeContextStateV0(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+	var raw struct {
+		ID               *string `json:"id"`
+		Name             *string `json:"name"`
+		Organization     *string `json:"organization"`
+		RecreateOnRename *bool   `json:"recreate_on_rename"`
+		OwnerID          *string `json:"owner_id"`
+		CreatedAt        *string `json:"created_at"`
+	}
+	if err := json.Unmarshal(req.RawState.JSON, &raw); err != nil {
+		resp.Diagnostics.AddError("Failed to upgrade context state", err.Error())
+		return
+	}
+
+	state := contextResourceModel{
+		ID:               types.StringPointerValue(raw.ID),
+		Name:             types.StringPointerValue(raw.Name),
+		Organization:     types.StringPointerValue(raw.Organization),
+		RecreateOnRename: types.BoolValue(raw.RecreateOnRename != nil && *raw.RecreateOnRename),
+		OwnerID:          types.StringPointerValue(raw.OwnerID),
+		CreatedAt:        types.StringPointerValue(raw.CreatedAt),
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *contextResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	parts := strings.Split(req.ID, "/")
 	if len(parts) != 2 {
-		return nil, errors.New("importing context requires $organization/$context")
+		resp.Diagnostics.AddError("Invalid import ID", "importing context requires $organization/$context")
+		return
+	}
+
+	found, err := r.client.GetContextByIDOrName(parts[0], parts[1])
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to get context", organizationAccessError(r.client, err, parts[0]).Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), found.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("organization"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("recreate_on_rename"), false)...)
+}
+
+// read refreshes the model from the API. It returns false when the context does not exist anymore.
+func (r *contextResource) read(model *contextResourceModel) (bool, error) {
+	found, err := r.client.GetContext(model.ID.ValueString())
+	if err != nil {
+		if errors.Is(err, client.ErrContextNotFound) {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	organization, err := r.client.Organization(model.Organization.ValueString())
+	if err != nil {
+		return false, err
 	}
 
-	ctx, err := c.GetContextByIDOrName(parts[0], parts[1])
+	ownerID, err := r.client.GetOrganizationID(organization)
 	if err != nil {
-		return nil, err
+		return false, err
 	}
 
-	d.SetId(ctx.ID)
+	model.Name = types.StringValue(found.Name)
+	model.Organization = types.StringValue(organization)
+	model.OwnerID = types.StringValue(ownerID)
+	model.CreatedAt = types.StringValue(found.CreatedAt.Format(time.RFC3339))
 
-	return []*schema.ResourceData{d}, nil
+	return true, nil
 }
@@ -3,26 +3,33 @@ package circleci
 import (
 	"errors"
 	"fmt"
+	"math"
 	"os"
 	"strings"
+	"time"
 
-	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 
 	client "github.com/mrolla/terraform-provider-circleci/circleci/client"
 )
 
 func resourceCircleCIContextEnvironmentVariable() *schema.Resource {
 	return &schema.Resource{
-		// Create and Update have the same implementation, since the upstream API uses PUT
 		Create: resourceCircleCIContextEnvironmentVariableCreate,
-		Update: resourceCircleCIContextEnvironmentVariableCreate,
-
 		Read:   resourceCircleCIContextEnvironmentVariableRead,
+		Update: resourceCircleCIContextEnvironmentVariableUpdate,
 		Delete: resourceCircleCIContextEnvironmentVariableDelete,
 		Importer: &schema.ResourceImporter{
 			State: resourceCircleCIContextEnvironmentVariableImport,
 		},
 
+		CustomizeDiff: customdiff.Sequence(
+			hashValueOnDiff,
+			computedOnValueChange("created_at", "updated_at"),
+		),
+
 		Schema: map[string]*schema.Schema{
 			"variable": {
 				Type:         schema.TypeString,
@@ -32,13 +39,28 @@ func resourceCircleCIContextEnvironmentVariable() *schema.Resource {
 				ValidateFunc: validateEnvironmentVariableNameFunc,
 			},
 			"value": {
-				Type:      schema.TypeString,
-				Required:  true,
-				Sensitive: true,
-				StateFunc: func(value interface{}) string {
-					return hashString(value.(string))
-				},
-				Description: "The value that will be set for the environment variable.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Sensitive:    true,
+				ExactlyOneOf: []string{"value", "value_wo"},
+				Description:  "The value that will be set for the environment variable.",
+			},
+			"value_wo": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				WriteOnly:    true,
+				ExactlyOneOf: []string{"value", "value_wo"},
+				RequiredWith: []string{"value_wo_version"},
+				Description:  "The value that will be set for the environment variable, which is never stored in the state.",
+			},
+			"value_wo_version": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				RequiredWith: []string{"value_wo"},
+				ValidateFunc: validation.IntBetween(1, math.MaxInt32),
+				Description:  "The version of value_wo. Changing it stores the current value_wo.",
 			},
 			"context_id": {
 				Type:        schema.TypeString,
@@ -52,6 +74,16 @@ func resourceCircleCIContextEnvironmentVariable() *schema.Resource {
 				ForceNew:    true,
 				Description: "The organization where the context is defined",
 			},
+			"created_at": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The creation date of the environment variable",
+			},
+			"updated_at": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The date the value of the environment variable was last changed",
+			},
 		},
 	}
 }
@@ -61,7 +93,11 @@ func resourceCircleCIContextEnvironmentVariableCreate(d *schema.ResourceData, m
 
 	variable := d.Get("variable").(string)
 	context := d.Get("context_id").(string)
-	value := d.Get("value").(string)
+
+	value, err := environmentVariableValue(d, c)
+	if err != nil {
+		return err
+	}
 
 	if err := c.CreateOrUpdateContextEnvironmentVariable(context, variable, value); err != nil {
 		return fmt.Errorf("error storing environment variable: %w", err)
@@ -69,24 +105,49 @@ func resourceCircleCIContextEnvironmentVariableCreate(d *schema.ResourceData, m
 
 	d.SetId(variable)
 
+	// The new value is not drift, so the timestamps are read again
+	_ = d.Set("created_at", "")
+	_ = d.Set("updated_at", "")
+
 	return resourceCircleCIContextEnvironmentVariableRead(d, m)
 }
 
+// resourceCircleCIContextEnvironmentVariableUpdate stores the value again, since the upstream API uses PUT
+func resourceCircleCIContextEnvironmentVariableUpdate(d *schema.ResourceData, m interface{}) error {
+	if !d.HasChanges("value", "value_wo_version") {
+		return resourceCircleCIContextEnvironmentVariableRead(d, m)
+	}
+
+	return resourceCircleCIContextEnvironmentVariableCreate(d, m)
+}
+
 func resourceCircleCIContextEnvironmentVariableRead(d *schema.ResourceData, m interface{}) error {
 	c := m.(*client.Client)
 
 	ctx := d.Get("context_id").(string)
 	variable := d.Get("variable").(string)
 
-	has, err := c.HasContextEnvironmentVariable(ctx, variable)
+	env, err := c.GetContextEnvironmentVariable(ctx, variable)
 	if err != nil {
+		if errors.Is(err, client.ErrContextEnvironmentVariableNotFound) {
+			d.SetId("")
+			return nil
+		}
+
 		return fmt.Errorf("failed to get context environment variables: %w", err)
 	}
 
-	if !has {
-		d.SetId("")
+	createdAt := env.CreatedAt.Format(time.RFC3339)
+	updatedAt := env.UpdatedAt.Format(time.RFC3339)
+
+	// A value changed outside of Terraform has a different update date.
+	if stored := d.Get("updated_at").(string); stored != "" && stored != updatedAt {
+		clearEnvironmentVariableValue(d)
 	}
 
+	_ = d.Set("created_at", createdAt)
+	_ = d.Set("updated_at", updatedAt)
+
 	return nil
 }
 
@@ -107,7 +168,7 @@ func resourceCircleCIContextEnvironmentVariableImport(d *schema.ResourceData, m
 	if value == "" {
 		return nil, errors.New("CIRCLECI_ENV_VALUE is required to import a context environment variable")
 	}
-	_ = d.Set("value", value)
+	_ = d.Set("value", c.HashValue(value))
 
 	parts := strings.Split(d.Id(), "/")
 	if len(parts) != 3 {
@@ -1,24 +1,29 @@
 package circleci
 
 import (
+	"context"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"testing"
 
 	"github.com/CircleCI-Public/circleci-cli/api"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
-	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
 
 	client "github.com/mrolla/terraform-provider-circleci/circleci/client"
 )
 
 func TestAccCircleCIContextEnvironmentVariable_basic(t *testing.T) {
-	variable := &api.EnvironmentVariable{}
+	variable := &client.ContextEnvironmentVariable{}
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccOrgProviders,
-		CheckDestroy: testAccCheckCircleCIContextEnvironmentVariableDestroy,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccOrgProviders,
+		CheckDestroy:             testAccCheckCircleCIContextEnvironmentVariableDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccCircleCIContextEnvironmentVariable_basic,
@@ -28,6 +33,8 @@ func TestAccCircleCIContextEnvironmentVariable_basic(t *testing.T) {
 					resource.TestCheckResourceAttr("circleci_context_environment_variable.foo", "variable", "VAR"),
 					resource.TestCheckResourceAttr("circleci_context_environment_variable.foo", "value", hashString("secret-value")),
 					resource.TestCheckResourceAttrSet("circleci_context_environment_variable.foo", "context_id"),
+					resource.TestCheckResourceAttrSet("circleci_context_environment_variable.foo", "created_at"),
+					resource.TestCheckResourceAttrSet("circleci_context_environment_variable.foo", "updated_at"),
 				),
 			},
 		},
@@ -35,12 +42,12 @@ func TestAccCircleCIContextEnvironmentVariable_basic(t *testing.T) {
 }
 
 func TestAccCircleCIContextEnvironmentVariable_update(t *testing.T) {
-	variable := &api.EnvironmentVariable{}
+	variable := &client.ContextEnvironmentVariable{}
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccOrgProviders,
-		CheckDestroy: testAccCheckCircleCIContextEnvironmentVariableDestroy,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccOrgProviders,
+		CheckDestroy:             testAccCheckCircleCIContextEnvironmentVariableDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccCircleCIContextEnvironmentVariable_basic,
@@ -66,13 +73,38 @@ func TestAccCircleCIContextEnvironmentVariable_update(t *testing.T) {
 	})
 }
 
+func TestAccCircleCIContextEnvironmentVariable_writeOnly(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccOrgProviders,
+		CheckDestroy:             testAccCheckCircleCIContextEnvironmentVariableDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCircleCIContextEnvironmentVariableWriteOnly("secret-value", 1),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckNoResourceAttr("circleci_context_environment_variable.foo", "value"),
+					resource.TestCheckNoResourceAttr("circleci_context_environment_variable.foo", "value_wo"),
+					resource.TestCheckResourceAttr("circleci_context_environment_variable.foo", "value_wo_version", "1"),
+				),
+			},
+			{
+				Config: testAccCircleCIContextEnvironmentVariableWriteOnly("secret-value-updated", 2),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckNoResourceAttr("circleci_context_environment_variable.foo", "value_wo"),
+					resource.TestCheckResourceAttr("circleci_context_environment_variable.foo", "value_wo_version", "2"),
+				),
+			},
+		},
+	})
+}
+
 func TestAccCircleCIContextEnvironmentVariable_import(t *testing.T) {
 	context := &api.Context{}
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccOrgProviders,
-		CheckDestroy: testAccCheckCircleCIContextEnvironmentVariableDestroy,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccOrgProviders,
+		CheckDestroy:             testAccCheckCircleCIContextEnvironmentVariableDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccCircleCIContextEnvironmentVariable_basic,
@@ -118,9 +150,9 @@ func TestAccCircleCIContextEnvironmentVariable_import(t *testing.T) {
 
 func TestAccCircleCIContextEnvironmentVariable_import_name(t *testing.T) {
 	resource.Test(t, resource.TestCase{
-		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccOrgProviders,
-		CheckDestroy: testAccCheckCircleCIContextEnvironmentVariableDestroy,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccOrgProviders,
+		CheckDestroy:             testAccCheckCircleCIContextEnvironmentVariableDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccCircleCIContextEnvironmentVariable_basic,
@@ -144,7 +176,75 @@ func TestAccCircleCIContextEnvironmentVariable_import_name(t *testing.T) {
 	})
 }
 
-func testAccCheckCircleCIContextEnvironmentVariableExists(addr string, variable *api.EnvironmentVariable) resource.TestCheckFunc {
+func TestContextEnvironmentVariableReadDrift(t *testing.T) {
+	r := resourceCircleCIContextEnvironmentVariable()
+	config := map[string]interface{}{
+		"context_id":       "context",
+		"variable":         "NAME",
+		"value_wo":         "secret",
+		"value_wo_version": 1,
+	}
+	rawConfig := testRawConfig(r, map[string]cty.Value{
+		"context_id":       cty.StringVal("context"),
+		"variable":         cty.StringVal("NAME"),
+		"value_wo":         cty.StringVal("secret"),
+		"value_wo_version": cty.NumberIntVal(1),
+	})
+
+	cases := []struct {
+		name      string
+		updatedAt string
+		drift     bool
+	}{
+		{"unchanged", "2024-01-01T00:00:00Z", false},
+		{"update date", "2024-02-01T00:00:00Z", true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/api/v2/context/context/environment-variable", r.URL.Path)
+				_, _ = fmt.Fprintf(w, `{"items": [{"variable": "NAME", "context_id": "context", "created_at": "2024-01-01T00:00:00Z", "updated_at": %q}]}`, tc.updatedAt)
+			}))
+			defer server.Close()
+
+			c, err := client.New(client.Config{URL: server.URL + "/api/v2/", Token: "token", VCS: "github"})
+			assert.NoError(t, err)
+
+			d := r.Data(&terraform.InstanceState{
+				ID: "NAME",
+				Attributes: map[string]string{
+					"id":               "NAME",
+					"context_id":       "context",
+					"variable":         "NAME",
+					"value":            "",
+					"value_wo_version": "1",
+					"created_at":       "2024-01-01T00:00:00Z",
+					"updated_at":       "2024-01-01T00:00:00Z",
+				},
+			})
+			assert.NoError(t, resourceCircleCIContextEnvironmentVariableRead(d, c))
+
+			// A variable changed outside of Terraform plans an update back to the configured value_wo
+			state := d.State()
+			state.RawConfig = rawConfig
+			diff, err := r.SimpleDiff(context.Background(), state, terraform.NewResourceConfigRaw(config), c)
+			assert.NoError(t, err)
+			if tc.drift {
+				if assert.Contains(t, diff.Attributes, "value_wo_version") && assert.Contains(t, diff.Attributes, "updated_at") {
+					assert.Equal(t, "1", diff.Attributes["value_wo_version"].New)
+					assert.True(t, diff.Attributes["updated_at"].NewComputed)
+				}
+			} else {
+				// The write-only value_wo is never planned, so only the stored attributes are checked
+				assert.NotContains(t, diff.Attributes, "value_wo_version")
+				assert.NotContains(t, diff.Attributes, "updated_at")
+			}
+		})
+	}
+}
+
+func testAccCheckCircleCIContextEnvironmentVariableExists(addr string, variable *client.ContextEnvironmentVariable) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		c := testAccOrgProvider.Meta().(*client.Client)
 
@@ -197,7 +297,7 @@ func testAccCheckCircleCIContextEnvironmentVariableDestroy(s *terraform.State) e
 	return nil
 }
 
-func testAccCheckCircleCIContextEnvironmentVariableAttributes_basic(variable *api.EnvironmentVariable) resource.TestCheckFunc {
+func testAccCheckCircleCIContextEnvironmentVariableAttributes_basic(variable *client.ContextEnvironmentVariable) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		if variable.Variable != "VAR" {
 			return fmt.Errorf("Unexpected variable: %s", variable.Variable)
@@ -207,7 +307,7 @@ func testAccCheckCircleCIContextEnvironmentVariableAttributes_basic(variable *ap
 	}
 }
 
-func testAccCheckCircleCIContextEnvironmentVariableAttributes_update(variable *api.EnvironmentVariable) resource.TestCheckFunc {
+func testAccCheckCircleCIContextEnvironmentVariableAttributes_update(variable *client.ContextEnvironmentVariable) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		if variable.Variable != "VAR_UPDATED" {
 			return fmt.Errorf("Unexpected variable: %s", variable.Variable)
@@ -240,3 +340,18 @@ resource "circleci_context_environment_variable" "foo" {
 	context_id = circleci_context.foo.id
 }
 `
+
+func testAccCircleCIContextEnvironmentVariableWriteOnly(value string, version int) string {
+	return fmt.Sprintf(`
+resource "circleci_context" "foo" {
+	name = "terraform-test"
+}
+
+resource "circleci_context_environment_variable" "foo" {
+	variable         = "VAR"
+	value_wo         = "%s"
+	value_wo_version = %d
+	context_id       = circleci_context.foo.id
+}
+`, value, version)
+}
@@ -0,0 +1,321 @@
+package circleci
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	client "github.com/mrolla/terraform-provider-circleci/circleci/client"
+)
+
+func resourceCircleCIContextEnvironmentVariables() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceCircleCIContextEnvironmentVariablesCreate,
+		Read:   resourceCircleCIContextEnvironmentVariablesRead,
+		Update: resourceCircleCIContextEnvironmentVariablesUpdate,
+		Delete: resourceCircleCIContextEnvironmentVariablesDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceCircleCIContextEnvironmentVariablesImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"context_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The ID of the context where the environment variables are defined",
+			},
+			"variables": {
+				Type:         schema.TypeMap,
+				Optional:     true,
+				Computed:     true,
+				Sensitive:    true,
+				Elem:         &schema.Schema{Type: schema.TypeString},
+				ValidateFunc: validateEnvironmentVariableNamesFunc,
+				Description:  "All environment variables of the context, by name. Hashes of the values are stored in the state.",
+			},
+		},
+
+		CustomizeDiff: hashEnvironmentVariablesOnDiff,
+	}
+}
+
+func resourceCircleCIContextEnvironmentVariablesCreate(d *schema.ResourceData, m interface{}) error {
+	c := m.(*client.Client)
+
+	ctx := d.Get("context_id").(string)
+
+	values, err := configuredEnvironmentVariables(d)
+	if err != nil {
+		return err
+	}
+
+	envs, err := c.ListContextEnvironmentVariables(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get context environment variables: %w", err)
+	}
+
+	names := make([]string, 0, len(*envs))
+	for _, env := range *envs {
+		names = append(names, env.Variable)
+	}
+
+	// Creating the resource would delete the variables which are not configured, without showing them in the plan
+	if unmanaged := unmanagedEnvironmentVariables(names, values); len(unmanaged) > 0 {
+		return fmt.Errorf("context %s already has environment variables which are not configured: %s. Add them to variables, or import the existing variables with terraform import and review the plan", ctx, strings.Join(unmanaged, ", "))
+	}
+
+	// Configured variables which already exist are overwritten
+	old := map[string]interface{}{}
+	for _, name := range names {
+		old[name] = ""
+	}
+
+	d.SetId(ctx)
+
+	if err := resourceCircleCIContextEnvironmentVariablesApply(c, d, old, values); err != nil {
+		return err
+	}
+
+	return resourceCircleCIContextEnvironmentVariablesRead(d, m)
+}
+
+func resourceCircleCIContextEnvironmentVariablesRead(d *schema.ResourceData, m interface{}) error {
+	c := m.(*client.Client)
+
+	envs, err := c.ListContextEnvironmentVariables(d.Id())
+	if err != nil {
+		if errors.Is(err, client.ErrContextNotFound) {
+			d.SetId("")
+			return nil
+		}
+
+		return fmt.Errorf("failed to get context environment variables: %w", err)
+	}
+
+	names := make([]string, 0, len(*envs))
+	for _, env := range *envs {
+		names = append(names, env.Variable)
+	}
+
+	_ = d.Set("context_id", d.Id())
+	_ = d.Set("variables", refreshEnvironmentVariableHashes(d.Get("variables").(map[string]interface{}), names))
+
+	return nil
+}
+
+func resourceCircleCIContextEnvironmentVariablesUpdate(d *schema.ResourceData, m interface{}) error {
+	c := m.(*client.Client)
+
+	values, err := configuredEnvironmentVariables(d)
+	if err != nil {
+		return err
+	}
+
+	old, _ := d.GetChange("variables")
+
+	if err := resourceCircleCIContextEnvironmentVariablesApply(c, d, old.(map[string]interface{}), values); err != nil {
+		return err
+	}
+
+	return resourceCircleCIContextEnvironmentVariablesRead(d, m)
+}
+
+func resourceCircleCIContextEnvironmentVariablesDelete(d *schema.ResourceData, m interface{}) error {
+	c := m.(*client.Client)
+
+	for name := range d.Get("variables").(map[string]interface{}) {
+		if err := c.DeleteContextEnvironmentVariable(d.Id(), name); err != nil {
+			return fmt.Errorf("error deleting environment variable %s: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+func resourceCircleCIContextEnvironmentVariablesImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	c := m.(*client.Client)
+
+	parts := strings.Split(d.Id(), "/")
+	if len(parts) != 2 {
+		return nil, errors.New("importing context environment variables requires $organization/$context")
+	}
+
+	ctx, err := c.GetContextByIDOrName(parts[0], parts[1])
+	if err != nil {
+		return nil, err
+	}
+
+	d.SetId(ctx.ID)
+	_ = d.Set("context_id", ctx.ID)
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// resourceCircleCIContextEnvironmentVariablesApply stores the configured variables which differ from the
+// old hashes and deletes the old variables which are not configured anymore.
+func resourceCircleCIContextEnvironmentVariablesApply(c *client.Client, d *schema.ResourceData, old map[string]interface{}, values map[string]string) error {
+	ctx := d.Id()
+
+	return applyEnvironmentVariables(c, d, old, values,
+		func(name, value string) error {
+			return c.CreateOrUpdateContextEnvironmentVariable(ctx, name, value)
+		},
+		func(name string) error {
+			return c.DeleteContextEnvironmentVariable(ctx, name)
+		},
+	)
+}
+
+// applyEnvironmentVariables stores the configured values which changed and deletes the removed variables.
+// The SDK saves the set attributes to the state when an apply fails, so the variables are set before each
+// request. A failed apply leaves the hashes of the variables stored so far, and the old hashes of the others.
+func applyEnvironmentVariables(c *client.Client, d *schema.ResourceData, old map[string]interface{}, values map[string]string, upsert func(name, value string) error, remove func(name string) error) error {
+	new := map[string]interface{}{}
+	for name, value := range values {
+		new[name] = c.HashValue(value)
+	}
+
+	upserts, deletes := diffEnvironmentVariables(old, new)
+
+	// Until they are applied, the changes are not recorded: unchanged and deleted variables keep their
+	// hash, and stored variables keep their old hash, or are not managed yet
+	applied := map[string]interface{}{}
+	for name, hash := range old {
+		applied[name] = hash
+	}
+
+	if err := d.Set("variables", applied); err != nil {
+		return err
+	}
+
+	for _, name := range upserts {
+		if err := upsert(name, values[name]); err != nil {
+			return fmt.Errorf("error storing environment variable %s: %w", name, err)
+		}
+
+		applied[name] = new[name]
+		if err := d.Set("variables", applied); err != nil {
+			return err
+		}
+	}
+
+	for _, name := range deletes {
+		if err := remove(name); err != nil {
+			return fmt.Errorf("error deleting environment variable %s: %w", name, err)
+		}
+
+		delete(applied, name)
+		if err := d.Set("variables", applied); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// hashEnvironmentVariablesOnDiff plans the hashes of the configured values, so that the values themselves
+// are never stored in the state. The hashes are keyed with the value_hash_key of the provider.
+func hashEnvironmentVariablesOnDiff(_ context.Context, d *schema.ResourceDiff, m interface{}) error {
+	c := m.(*client.Client)
+
+	config, diags := d.GetRawConfigAt(cty.GetAttrPath("variables"))
+	if diags.HasError() {
+		return fmt.Errorf("failed to get variables: %v", diags)
+	}
+
+	// The hashes are planned once all the values are known
+	if !config.IsWhollyKnown() {
+		return nil
+	}
+
+	hashes := map[string]interface{}{}
+	for name, value := range environmentVariablesFromConfig(config) {
+		hashes[name] = c.HashValue(value)
+	}
+
+	return d.SetNew("variables", hashes)
+}
+
+// configuredEnvironmentVariables returns the configured values of the variables. Only their hashes
+// are planned, so the values are read from the raw configuration.
+func configuredEnvironmentVariables(d *schema.ResourceData) (map[string]string, error) {
+	config, diags := d.GetRawConfigAt(cty.GetAttrPath("variables"))
+	if diags.HasError() {
+		return nil, fmt.Errorf("failed to get variables: %v", diags)
+	}
+
+	return environmentVariablesFromConfig(config), nil
+}
+
+func environmentVariablesFromConfig(config cty.Value) map[string]string {
+	values := map[string]string{}
+	if config.IsNull() || !config.IsKnown() {
+		return values
+	}
+
+	for name, value := range config.AsValueMap() {
+		if !value.IsNull() && value.IsKnown() {
+			values[name] = value.AsString()
+		} else {
+			values[name] = ""
+		}
+	}
+
+	return values
+}
+
+// diffEnvironmentVariables compares the old hashes of the variables with the new ones. It returns the sorted
+// names of the variables to store and of the variables to delete.
+func diffEnvironmentVariables(old, new map[string]interface{}) ([]string, []string) {
+	var upserts []string
+	for name, hash := range new {
+		if oldHash, ok := old[name]; !ok || oldHash != hash {
+			upserts = append(upserts, name)
+		}
+	}
+	sort.Strings(upserts)
+
+	var deletes []string
+	for name := range old {
+		if _, ok := new[name]; !ok {
+			deletes = append(deletes, name)
+		}
+	}
+	sort.Strings(deletes)
+
+	return upserts, deletes
+}
+
+// unmanagedEnvironmentVariables returns the sorted names of the existing variables which are not configured
+func unmanagedEnvironmentVariables(names []string, configured map[string]string) []string {
+	var unmanaged []string
+	for _, name := range names {
+		if _, ok := configured[name]; !ok {
+			unmanaged = append(unmanaged, name)
+		}
+	}
+	sort.Strings(unmanaged)
+
+	return unmanaged
+}
+
+// refreshEnvironmentVariableHashes returns the hashes of the existing variables. Variables which are not
+// managed yet get an empty hash, so that they show up as changes.
+func refreshEnvironmentVariableHashes(hashes map[string]interface{}, names []string) map[string]interface{} {
+	refreshed := map[string]interface{}{}
+	for _, name := range names {
+		if hash, ok := hashes[name]; ok {
+			refreshed[name] = hash
+		} else {
+			refreshed[name] = ""
+		}
+	}
+
+	return refreshed
+}
@@ -0,0 +1,255 @@
+package circleci
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+
+	client "github.com/mrolla/terraform-provider-circleci/circleci/client"
+)
+
+func TestAccCircleCIContextEnvironmentVariables_basic(t *testing.T) {
+	context := acctest.RandomWithPrefix(t.Name())
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccOrgProviders,
+		CheckDestroy:             testAccCheckCircleCIContextEnvironmentVariablesDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCircleCIContextEnvironmentVariablesConfig(context, map[string]string{
+					"TOKEN_A": "secret",
+					"TOKEN_B": "secret",
+				}),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("circleci_context_environment_variables.foo", "variables.%", "2"),
+					resource.TestCheckResourceAttr("circleci_context_environment_variables.foo", "variables.TOKEN_A", hashString("secret")),
+					resource.TestCheckResourceAttr("circleci_context_environment_variables.foo", "variables.TOKEN_B", hashString("secret")),
+				),
+			},
+			{
+				Config: testAccCircleCIContextEnvironmentVariablesConfig(context, map[string]string{
+					"TOKEN_A": "changed",
+					"TOKEN_C": "secret",
+				}),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("circleci_context_environment_variables.foo", "variables.%", "2"),
+					resource.TestCheckResourceAttr("circleci_context_environment_variables.foo", "variables.TOKEN_A", hashString("changed")),
+					resource.TestCheckResourceAttr("circleci_context_environment_variables.foo", "variables.TOKEN_C", hashString("secret")),
+				),
+			},
+		},
+	})
+}
+
+func TestDiffEnvironmentVariables(t *testing.T) {
+	old := map[string]interface{}{
+		"KEPT":      hashString("kept"),
+		"CHANGED":   hashString("old"),
+		"REMOVED":   hashString("removed"),
+		"UNMANAGED": "",
+	}
+	new := map[string]interface{}{
+		"KEPT":    hashString("kept"),
+		"CHANGED": hashString("new"),
+		"ADDED":   hashString("added"),
+	}
+
+	upserts, deletes := diffEnvironmentVariables(old, new)
+
+	assert.Equal(t, []string{"ADDED", "CHANGED"}, upserts)
+	assert.Equal(t, []string{"REMOVED", "UNMANAGED"}, deletes)
+}
+
+func TestHashEnvironmentVariablesOnDiff(t *testing.T) {
+	r := resourceCircleCIContextEnvironmentVariables()
+	config := map[string]interface{}{
+		"context_id": "context",
+		"variables": map[string]interface{}{
+			"KEPT":  "kept",
+			"ADDED": "added",
+		},
+	}
+
+	c, err := client.New(client.Config{URL: "https://circleci.com/api/v2/", ValueHashKey: "key"})
+	assert.NoError(t, err)
+
+	state := &terraform.InstanceState{
+		ID: "context",
+		Attributes: map[string]string{
+			"id":             "context",
+			"context_id":     "context",
+			"variables.%":    "2",
+			"variables.KEPT": c.HashValue("kept"),
+			"variables.GONE": c.HashValue("gone"),
+		},
+		RawConfig: testRawConfig(r, map[string]cty.Value{
+			"context_id": cty.StringVal("context"),
+			"variables": cty.MapVal(map[string]cty.Value{
+				"KEPT":  cty.StringVal("kept"),
+				"ADDED": cty.StringVal("added"),
+			}),
+		}),
+	}
+
+	diff, err := r.SimpleDiff(context.Background(), state, terraform.NewResourceConfigRaw(config), c)
+	assert.NoError(t, err)
+
+	// Only hashes keyed by the client are planned
+	assert.Equal(t, c.HashValue("added"), diff.Attributes["variables.ADDED"].New)
+	assert.True(t, diff.Attributes["variables.GONE"].NewRemoved)
+	assert.NotContains(t, diff.Attributes, "variables.KEPT")
+}
+
+func TestApplyEnvironmentVariables(t *testing.T) {
+	old := map[string]interface{}{
+		"KEPT":    hashString("kept"),
+		"CHANGED": hashString("old"),
+		"REMOVED": hashString("removed"),
+	}
+
+	d := schema.TestResourceDataRaw(t, resourceCircleCIContextEnvironmentVariables().Schema, map[string]interface{}{
+		"context_id": "context",
+	})
+	d.SetId("context")
+
+	var requests []string
+	err := applyEnvironmentVariables(&client.Client{}, d, old,
+		map[string]string{
+			"KEPT":    "kept",
+			"CHANGED": "new",
+			"ADDED":   "added",
+		},
+		func(name, value string) error {
+			requests = append(requests, "store "+name+"="+value)
+			return nil
+		},
+		func(name string) error {
+			requests = append(requests, "delete "+name)
+			return nil
+		},
+	)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"store ADDED=added", "store CHANGED=new", "delete REMOVED"}, requests)
+	assert.Equal(t, map[string]interface{}{
+		"KEPT":    hashString("kept"),
+		"CHANGED": hashString("new"),
+		"ADDED":   hashString("added"),
+	}, d.Get("variables"))
+}
+
+func TestApplyEnvironmentVariablesFailure(t *testing.T) {
+	old := map[string]interface{}{
+		"KEPT":    hashString("kept"),
+		"CHANGED": hashString("old"),
+		"REMOVED": hashString("removed"),
+	}
+
+	d := schema.TestResourceDataRaw(t, resourceCircleCIContextEnvironmentVariables().Schema, map[string]interface{}{
+		"context_id": "context",
+	})
+	d.SetId("context")
+
+	// The apply fails halfway, after storing ADDED and CHANGED
+	err := applyEnvironmentVariables(&client.Client{}, d, old,
+		map[string]string{
+			"KEPT":    "kept",
+			"CHANGED": "secret-new",
+			"ADDED":   "secret-added",
+			"FAILED":  "secret-failed",
+		},
+		func(name, value string) error {
+			if name == "FAILED" {
+				return errors.New("internal server error")
+			}
+			return nil
+		},
+		func(name string) error {
+			return nil
+		},
+	)
+	assert.EqualError(t, err, "error storing environment variable FAILED: internal server error")
+
+	// The state records the applied changes, and never holds plaintext values
+	state := d.State()
+	assert.Equal(t, hashString("kept"), state.Attributes["variables.KEPT"])
+	assert.Equal(t, hashString("secret-new"), state.Attributes["variables.CHANGED"])
+	assert.Equal(t, hashString("secret-added"), state.Attributes["variables.ADDED"])
+	assert.Equal(t, hashString("removed"), state.Attributes["variables.REMOVED"])
+	assert.NotContains(t, state.Attributes, "variables.FAILED")
+	for key, value := range state.Attributes {
+		assert.NotContains(t, value, "secret-", key)
+	}
+}
+
+func TestUnmanagedEnvironmentVariables(t *testing.T) {
+	configured := map[string]string{
+		"CONFIGURED": "value",
+	}
+
+	assert.Equal(t, []string{"A_UNMANAGED", "B_UNMANAGED"}, unmanagedEnvironmentVariables([]string{"B_UNMANAGED", "CONFIGURED", "A_UNMANAGED"}, configured))
+	assert.Empty(t, unmanagedEnvironmentVariables([]string{"CONFIGURED"}, configured))
+}
+
+func TestRefreshEnvironmentVariableHashes(t *testing.T) {
+	hashes := map[string]interface{}{
+		"KEPT":    hashString("kept"),
+		"REMOVED": hashString("removed"),
+	}
+
+	assert.Equal(t, map[string]interface{}{
+		"KEPT":      hashString("kept"),
+		"UNMANAGED": "",
+	}, refreshEnvironmentVariableHashes(hashes, []string{"KEPT", "UNMANAGED"}))
+}
+
+func testAccCheckCircleCIContextEnvironmentVariablesDestroy(s *terraform.State) error {
+	c := testAccOrgProvider.Meta().(*client.Client)
+
+	for _, resource := range s.RootModule().Resources {
+		if resource.Type != "circleci_context_environment_variables" {
+			continue
+		}
+
+		if resource.Primary.ID == "" {
+			return fmt.Errorf("No context ID is set")
+		}
+
+		envs, err := c.ListContextEnvironmentVariables(resource.Primary.ID)
+		if err == nil && len(*envs) > 0 {
+			return fmt.Errorf("Context %s still has environment variables", resource.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+func testAccCircleCIContextEnvironmentVariablesConfig(context string, variables map[string]string) string {
+	config := fmt.Sprintf(`
+resource "circleci_context" "foo" {
+  name         = "%s"
+  organization = "%s"
+}
+
+resource "circleci_context_environment_variables" "foo" {
+  context_id = circleci_context.foo.id
+
+  variables = {
+`, context, os.Getenv("TEST_CIRCLECI_ORGANIZATION"))
+
+	for name, value := range variables {
+		config += fmt.Sprintf("    %s = %q\n", name, value)
+	}
+
+	return config + "  }\n}\n"
+}
@@ -0,0 +1,108 @@
+package circleci
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	client "github.com/mrolla/terraform-provider-circleci/circleci/client"
+)
+
+func resourceCircleCIContextRestriction() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceCircleCIContextRestrictionCreate,
+		Read:   resourceCircleCIContextRestrictionRead,
+		Delete: resourceCircleCIContextRestrictionDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceCircleCIContextRestrictionImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"context_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The ID of the context to restrict",
+			},
+			"type": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "The type of restriction, either project, expression or group",
+				ValidateFunc: validation.StringInSlice([]string{"project", "expression", "group"}, false),
+			},
+			"value": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The project ID, expression or group ID the context is restricted to",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The name of the project or group the context is restricted to",
+			},
+		},
+	}
+}
+
+func resourceCircleCIContextRestrictionCreate(d *schema.ResourceData, m interface{}) error {
+	c := m.(*client.Client)
+
+	restriction, err := c.CreateContextRestriction(
+		d.Get("context_id").(string),
+		d.Get("type").(string),
+		d.Get("value").(string),
+	)
+	if err != nil {
+		return fmt.Errorf("error creating context restriction: %w", err)
+	}
+
+	d.SetId(restriction.ID)
+	return resourceCircleCIContextRestrictionRead(d, m)
+}
+
+func resourceCircleCIContextRestrictionRead(d *schema.ResourceData, m interface{}) error {
+	c := m.(*client.Client)
+
+	restriction, err := c.GetContextRestriction(d.Get("context_id").(string), d.Id())
+	if err != nil {
+		if errors.Is(err, client.ErrContextRestrictionNotFound) {
+			d.SetId("")
+			return nil
+		}
+
+		return fmt.Errorf("failed to get context restrictions: %w", err)
+	}
+
+	_ = d.Set("type", restriction.Type)
+	_ = d.Set("value", restriction.Value)
+	_ = d.Set("name", restriction.Name)
+
+	return nil
+}
+
+func resourceCircleCIContextRestrictionDelete(d *schema.ResourceData, m interface{}) error {
+	c := m.(*client.Client)
+
+	if err := c.DeleteContextRestriction(d.Get("context_id").(string), d.Id()); err != nil {
+		return fmt.Errorf("error deleting context restriction: %w", err)
+	}
+
+	return nil
+}
+
+func resourceCircleCIContextRestrictionImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.Split(d.Id(), "/")
+	if len(parts) != 2 {
+		return nil, errors.New("importing context restrictions requires $context/$restriction")
+	}
+
+	_ = d.Set("context_id", parts[0])
+	d.SetId(parts[1])
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,87 @@
+package circleci
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	client "github.com/mrolla/terraform-provider-circleci/circleci/client"
+)
+
+func TestAccCircleCIContextRestriction_basic(t *testing.T) {
+	project := os.Getenv("CIRCLECI_PROJECT")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccOrgProviders,
+		CheckDestroy:             testAccCheckCircleCIContextRestrictionDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCircleCIContextRestrictionConfig(project),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("circleci_context_restriction.project", "type", "project"),
+					resource.TestCheckResourceAttrPair("circleci_context_restriction.project", "value", "circleci_project.foo", "project_id"),
+					resource.TestCheckResourceAttr("circleci_context_restriction.expression", "type", "expression"),
+					resource.TestCheckResourceAttr("circleci_context_restriction.expression", "value", `pipeline.git.branch == "main"`),
+				),
+			},
+			{
+				ResourceName: "circleci_context_restriction.project",
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					rs, ok := s.RootModule().Resources["circleci_context_restriction.project"]
+					if !ok {
+						return "", errors.New("Not found: circleci_context_restriction.project")
+					}
+
+					return fmt.Sprintf("%s/%s", rs.Primary.Attributes["context_id"], rs.Primary.ID), nil
+				},
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckCircleCIContextRestrictionDestroy(s *terraform.State) error {
+	c := testAccOrgProvider.Meta().(*client.Client)
+
+	for _, resource := range s.RootModule().Resources {
+		if resource.Type != "circleci_context_restriction" {
+			continue
+		}
+
+		_, err := c.GetContextRestriction(resource.Primary.Attributes["context_id"], resource.Primary.ID)
+		if err == nil {
+			return fmt.Errorf("Context restriction %s still exists", resource.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+func testAccCircleCIContextRestrictionConfig(project string) string {
+	return fmt.Sprintf(`
+resource "circleci_project" "foo" {
+  name = "%s"
+}
+
+resource "circleci_context" "foo" {
+  name = "terraform-test"
+}
+
+resource "circleci_context_restriction" "project" {
+  context_id = circleci_context.foo.id
+  type       = "project"
+  value      = circleci_project.foo.project_id
+}
+
+resource "circleci_context_restriction" "expression" {
+  context_id = circleci_context.foo.id
+  type       = "expression"
+  value      = "pipeline.git.branch == \"main\""
+}`, project)
+}
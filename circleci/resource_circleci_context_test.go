@@ -3,11 +3,12 @@ package circleci
 import (
 	"fmt"
 	"os"
+	"regexp"
 	"testing"
 
 	"github.com/CircleCI-Public/circleci-cli/api"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
-	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 
 	client "github.com/mrolla/terraform-provider-circleci/circleci/client"
 )
@@ -16,9 +17,9 @@ func TestAccCircleCIContext_basic(t *testing.T) {
 	context := &api.Context{}
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccOrgProviders,
-		CheckDestroy: testAccCheckCircleCIContextDestroy,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccOrgProviders,
+		CheckDestroy:             testAccCheckCircleCIContextDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccCircleCIContext_basic,
@@ -26,6 +27,9 @@ func TestAccCircleCIContext_basic(t *testing.T) {
 					testAccCheckCircleCIContextExists("circleci_context.foo", context),
 					testAccCheckCircleCIContextAttributes_basic(context),
 					resource.TestCheckResourceAttr("circleci_context.foo", "name", "terraform-test"),
+					resource.TestCheckResourceAttr("circleci_context.foo", "organization", os.Getenv("TEST_CIRCLECI_ORGANIZATION")),
+					resource.TestCheckResourceAttrSet("circleci_context.foo", "owner_id"),
+					resource.TestCheckResourceAttrSet("circleci_context.foo", "created_at"),
 				),
 			},
 		},
@@ -36,9 +40,9 @@ func TestAccCircleCIContext_update(t *testing.T) {
 	context := &api.Context{}
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccOrgProviders,
-		CheckDestroy: testAccCheckCircleCIContextDestroy,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccOrgProviders,
+		CheckDestroy:             testAccCheckCircleCIContextDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccCircleCIContext_basic,
@@ -60,13 +64,30 @@ func TestAccCircleCIContext_update(t *testing.T) {
 	})
 }
 
+func TestAccCircleCIContext_rename_requires_recreate(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccOrgProviders,
+		CheckDestroy:             testAccCheckCircleCIContextDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCircleCIContext_basic,
+			},
+			{
+				Config:      testAccCircleCIContext_rename,
+				ExpectError: regexp.MustCompile("recreate_on_rename"),
+			},
+		},
+	})
+}
+
 func TestAccCircleCIContext_import(t *testing.T) {
 	context := &api.Context{}
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccOrgProviders,
-		CheckDestroy: testAccCheckCircleCIContextDestroy,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccOrgProviders,
+		CheckDestroy:             testAccCheckCircleCIContextDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccCircleCIContext_basic,
@@ -97,9 +118,9 @@ func TestAccCircleCIContext_import_name(t *testing.T) {
 	context := &api.Context{}
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccOrgProviders,
-		CheckDestroy: testAccCheckCircleCIContextDestroy,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccOrgProviders,
+		CheckDestroy:             testAccCheckCircleCIContextDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccCircleCIContext_basic,
@@ -190,6 +211,13 @@ resource "circleci_context" "foo" {
 `
 
 const testAccCircleCIContext_update = `
+resource "circleci_context" "foo" {
+	name               = "terraform-test-updated"
+	recreate_on_rename = true
+}
+`
+
+const testAccCircleCIContext_rename = `
 resource "circleci_context" "foo" {
 	name = "terraform-test-updated"
 }
@@ -1,13 +1,19 @@
 package circleci
 
 import (
+	"context"
 	"crypto/sha256"
 	"encoding/base64"
+	"errors"
 	"fmt"
+	"math"
 	"strings"
 	"time"
 
-	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 
 	client "github.com/mrolla/terraform-provider-circleci/circleci/client"
 )
@@ -16,6 +22,7 @@ func resourceCircleCIEnvironmentVariable() *schema.Resource {
 	return &schema.Resource{
 		Create: resourceCircleCIEnvironmentVariableCreate,
 		Read:   resourceCircleCIEnvironmentVariableRead,
+		Update: resourceCircleCIEnvironmentVariableUpdate,
 		Delete: resourceCircleCIEnvironmentVariableDelete,
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
@@ -23,9 +30,15 @@ func resourceCircleCIEnvironmentVariable() *schema.Resource {
 
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
 			Delete: schema.DefaultTimeout(5 * time.Minute),
 		},
 
+		CustomizeDiff: customdiff.Sequence(
+			hashValueOnDiff,
+			computedOnValueChange("masked_value", "created_at"),
+		),
+
 		Schema: map[string]*schema.Schema{
 			"organization": {
 				Description: "The CircleCI organization.",
@@ -34,7 +47,7 @@ func resourceCircleCIEnvironmentVariable() *schema.Resource {
 				ForceNew:    true,
 			},
 			"project": {
-				Description: "The name of the CircleCI project to create the variable in",
+				Description: "The name, ID or slug of the CircleCI project to create the variable in",
 				Type:        schema.TypeString,
 				Required:    true,
 				ForceNew:    true,
@@ -46,18 +59,41 @@ func resourceCircleCIEnvironmentVariable() *schema.Resource {
 				ForceNew:     true,
 				ValidateFunc: validateEnvironmentVariableNameFunc,
 			},
+			// To avoid storing the value of the environment variable in the state but still be
+			// able to know when the value changes, hashValueOnDiff plans a hash of the value
 			"value": {
-				Description: "The value of the environment variable",
+				Description:  "The value of the environment variable",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Sensitive:    true,
+				ExactlyOneOf: []string{"value", "value_wo"},
+			},
+			"value_wo": {
+				Description:  "The value of the environment variable, which is never stored in the state",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				WriteOnly:    true,
+				ExactlyOneOf: []string{"value", "value_wo"},
+				RequiredWith: []string{"value_wo_version"},
+			},
+			"value_wo_version": {
+				Description:  "The version of value_wo. Changing it stores the current value_wo",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				RequiredWith: []string{"value_wo"},
+				ValidateFunc: validation.IntBetween(1, math.MaxInt32),
+			},
+			"masked_value": {
+				Description: "The masked value of the environment variable, as returned by CircleCI",
 				Type:        schema.TypeString,
-				Required:    true,
-				ForceNew:    true,
-				Sensitive:   true,
-				StateFunc: func(value interface{}) string {
-					/* To avoid storing the value of the environment variable in the state
-					but still be able to know when the value change, we store a hash of the value.
-					*/
-					return hashString(value.(string))
-				},
+				Computed:    true,
+			},
+			"created_at": {
+				Description: "The creation date of the environment variable",
+				Type:        schema.TypeString,
+				Computed:    true,
 			},
 		},
 		SchemaVersion: 1,
@@ -110,7 +146,7 @@ func resourceCircleCIEnvironmentVariableResourceV0() *schema.Resource {
 	}
 }
 
-func resourceCircleCIEnvironmentVariableUpgradeV0(rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+func resourceCircleCIEnvironmentVariableUpgradeV0(_ context.Context, rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
 	var organization string
 	rawOrg := rawState["organization"]
 	if rawOrg != nil && rawOrg.(string) != "" {
@@ -138,17 +174,92 @@ func hashString(str string) string {
 	return base64.StdEncoding.EncodeToString(hash[:])
 }
 
+// hashValueOnDiff plans the hash of the configured value of an environment variable, so that the
+// value itself is never stored in the state. The hash is keyed with the value_hash_key of the provider.
+func hashValueOnDiff(_ context.Context, d *schema.ResourceDiff, m interface{}) error {
+	c := m.(*client.Client)
+
+	value, diags := d.GetRawConfigAt(cty.GetAttrPath("value"))
+	if diags.HasError() {
+		return fmt.Errorf("failed to get value: %v", diags)
+	}
+
+	switch {
+	case !value.IsKnown():
+		// The hash is planned once the value is known
+		return nil
+	case value.IsNull():
+		// value_wo is configured, and nothing is stored for it
+		return d.SetNew("value", "")
+	}
+
+	return d.SetNew("value", c.HashValue(value.AsString()))
+}
+
+// computedOnValueChange marks the given attributes as unknown when the value of an
+// environment variable changes, since CircleCI sets them when storing the value.
+func computedOnValueChange(keys ...string) schema.CustomizeDiffFunc {
+	return func(_ context.Context, d *schema.ResourceDiff, m interface{}) error {
+		if d.Id() == "" || !d.HasChanges("value", "value_wo_version") {
+			return nil
+		}
+
+		for _, key := range keys {
+			if err := d.SetNewComputed(key); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
+
+// environmentVariableValue returns the configured value of an environment variable, from either
+// value or the write-only value_wo, and stores the hash of value. Only the hash of value is planned,
+// so both are read from the raw configuration.
+func environmentVariableValue(d *schema.ResourceData, c *client.Client) (string, error) {
+	for _, key := range []string{"value", "value_wo"} {
+		value, diags := d.GetRawConfigAt(cty.GetAttrPath(key))
+		if diags.HasError() {
+			return "", fmt.Errorf("failed to get %s: %v", key, diags)
+		}
+
+		if value.IsNull() || !value.IsKnown() || !value.Type().Equals(cty.String) {
+			continue
+		}
+
+		if key == "value" {
+			_ = d.Set("value", c.HashValue(value.AsString()))
+		}
+
+		return value.AsString(), nil
+	}
+
+	return "", nil
+}
+
+// clearEnvironmentVariableValue plans an update of an environment variable back to its configured
+// value, by clearing the stored hash of value and the stored version of value_wo.
+func clearEnvironmentVariableValue(d *schema.ResourceData) {
+	_ = d.Set("value", "")
+	_ = d.Set("value_wo_version", 0)
+}
+
 func resourceCircleCIEnvironmentVariableCreate(d *schema.ResourceData, m interface{}) error {
 	c := m.(*client.Client)
 
-	organization, err := c.Organization(d.Get("organization").(string))
+	organization, err := c.ProjectOrganization(d.Get("organization").(string), d.Get("project").(string))
 	if err != nil {
 		return err
 	}
 
 	project := d.Get("project").(string)
 	name := d.Get("name").(string)
-	value := d.Get("value").(string)
+
+	value, err := environmentVariableValue(d, c)
+	if err != nil {
+		return err
+	}
 
 	has, err := c.HasProjectEnvironmentVariable(organization, project, name)
 	if err != nil {
@@ -177,7 +288,7 @@ func resourceCircleCIEnvironmentVariableRead(d *schema.ResourceData, m interface
 		}
 	}
 
-	organization, err := c.Organization(d.Get("organization").(string))
+	organization, err := c.ProjectOrganization(d.Get("organization").(string), d.Get("project").(string))
 	if err != nil {
 		return err
 	}
@@ -185,23 +296,69 @@ func resourceCircleCIEnvironmentVariableRead(d *schema.ResourceData, m interface
 	project := d.Get("project").(string)
 	name := d.Get("name").(string)
 
-	has, err := c.HasProjectEnvironmentVariable(organization, project, name)
+	env, err := c.GetProjectEnvironmentVariable(organization, project, name)
 	if err != nil {
+		if errors.Is(err, client.ErrProjectEnvironmentVariableNotFound) {
+			d.SetId("")
+			return nil
+		}
+
 		return fmt.Errorf("failed to get project environment variable: %w", err)
 	}
 
-	if !has {
-		d.SetId("")
-		return nil
+	var createdAt string
+	if env.CreatedAt != nil {
+		createdAt = env.CreatedAt.Format(time.RFC3339)
 	}
 
+	// A value changed outside of Terraform has a different masked value or creation date.
+	if masked := d.Get("masked_value").(string); masked != "" && (masked != env.Value || d.Get("created_at").(string) != createdAt) {
+		clearEnvironmentVariableValue(d)
+	}
+
+	_ = d.Set("masked_value", env.Value)
+	_ = d.Set("created_at", createdAt)
+
 	return nil
 }
 
+func resourceCircleCIEnvironmentVariableUpdate(d *schema.ResourceData, m interface{}) error {
+	// Nothing is stored when only the timeouts change
+	if !d.HasChanges("value", "value_wo_version") {
+		return resourceCircleCIEnvironmentVariableRead(d, m)
+	}
+
+	c := m.(*client.Client)
+
+	organization, err := c.ProjectOrganization(d.Get("organization").(string), d.Get("project").(string))
+	if err != nil {
+		return err
+	}
+
+	project := d.Get("project").(string)
+	name := d.Get("name").(string)
+
+	value, err := environmentVariableValue(d, c)
+	if err != nil {
+		return err
+	}
+
+	// Creating an environment variable replaces the value of an existing one
+	if err := c.CreateProjectEnvironmentVariable(organization, project, name, value); err != nil {
+		return fmt.Errorf("failed to update environment variable: %w", err)
+	}
+
+	// The new value is not drift, so the masked value and creation date are read again
+	_ = d.Set("masked_value", "")
+	_ = d.Set("created_at", "")
+
+	return resourceCircleCIEnvironmentVariableRead(d, m)
+}
+
 func resourceCircleCIEnvironmentVariableDelete(d *schema.ResourceData, m interface{}) error {
 	c := m.(*client.Client)
 
-	organization, err := c.Organization(d.Get("organization").(string))
+	organization, err := c.ProjectOrganization(d.Get("organization").(string), d.Get("project").(string))
 	if err != nil {
 		return err
 	}
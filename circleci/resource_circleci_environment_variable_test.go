@@ -1,15 +1,20 @@
 package circleci
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"regexp"
 	"testing"
 
-	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
-	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
-	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/stretchr/testify/assert"
 
 	client "github.com/mrolla/terraform-provider-circleci/circleci/client"
@@ -23,7 +28,7 @@ func TestAccCircleCIEnvironmentVariableOrganizationNotSet(t *testing.T) {
 		PreCheck: func() {
 			testAccPreCheck(t)
 		},
-		Providers: testAccNoOrgProviders,
+		ProtoV6ProviderFactories: testAccNoOrgProviders,
 		Steps: []resource.TestStep{
 			{
 				Config:      testAccCircleCIEnvironmentVariableConfigProviderOrg(project, envName, "value-for-the-test"),
@@ -43,8 +48,8 @@ func TestAccCircleCIEnvironmentVariableCreateThenUpdateProviderOrg(t *testing.T)
 		PreCheck: func() {
 			testAccPreCheck(t)
 		},
-		Providers:    testAccOrgProviders,
-		CheckDestroy: testAccCircleCIEnvironmentVariableProviderOrgCheckDestroy,
+		ProtoV6ProviderFactories: testAccOrgProviders,
+		CheckDestroy:             testAccCircleCIEnvironmentVariableProviderOrgCheckDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccCircleCIEnvironmentVariableConfigProviderOrg(project, envName, "value-for-the-test"),
@@ -53,6 +58,8 @@ func TestAccCircleCIEnvironmentVariableCreateThenUpdateProviderOrg(t *testing.T)
 					resource.TestCheckResourceAttr(resourceName, "project", project),
 					resource.TestCheckResourceAttr(resourceName, "name", envName),
 					resource.TestCheckResourceAttr(resourceName, "value", hashString("value-for-the-test")),
+					resource.TestCheckResourceAttr(resourceName, "masked_value", "xxxxtest"),
+					resource.TestCheckResourceAttrSet(resourceName, "created_at"),
 				),
 			},
 			{
@@ -79,8 +86,8 @@ func TestAccCircleCIEnvironmentVariableCreateThenUpdateResourceOrg(t *testing.T)
 		PreCheck: func() {
 			testAccPreCheck(t)
 		},
-		Providers:    testAccNoOrgProviders,
-		CheckDestroy: testAccCircleCIEnvironmentVariableResourceOrgCheckDestroy,
+		ProtoV6ProviderFactories: testAccNoOrgProviders,
+		CheckDestroy:             testAccCircleCIEnvironmentVariableResourceOrgCheckDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccCircleCIEnvironmentVariableConfigResourceOrg(organization, project, envName, "value-for-the-test"),
@@ -104,6 +111,39 @@ func TestAccCircleCIEnvironmentVariableCreateThenUpdateResourceOrg(t *testing.T)
 	})
 }
 
+func TestAccCircleCIEnvironmentVariableWriteOnly(t *testing.T) {
+	project := os.Getenv("CIRCLECI_PROJECT")
+	envName := "TEST_" + acctest.RandString(8)
+	resourceName := "circleci_environment_variable." + envName
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		ProtoV6ProviderFactories: testAccOrgProviders,
+		CheckDestroy:             testAccCircleCIEnvironmentVariableProviderOrgCheckDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCircleCIEnvironmentVariableConfigWriteOnly(project, envName, "value-for-the-test", 1),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckNoResourceAttr(resourceName, "value"),
+					resource.TestCheckNoResourceAttr(resourceName, "value_wo"),
+					resource.TestCheckResourceAttr(resourceName, "value_wo_version", "1"),
+					resource.TestCheckResourceAttr(resourceName, "masked_value", "xxxxtest"),
+				),
+			},
+			{
+				Config: testAccCircleCIEnvironmentVariableConfigWriteOnly(project, envName, "value-for-the-test-again", 2),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckNoResourceAttr(resourceName, "value_wo"),
+					resource.TestCheckResourceAttr(resourceName, "value_wo_version", "2"),
+					resource.TestCheckResourceAttr(resourceName, "masked_value", "xxxxgain"),
+				),
+			},
+		},
+	})
+}
+
 func TestAccCircleCIEnvironmentVariableCreateAlreadyExists(t *testing.T) {
 	project := os.Getenv("CIRCLECI_PROJECT")
 	envName := "TEST_" + acctest.RandString(8)
@@ -113,7 +153,7 @@ func TestAccCircleCIEnvironmentVariableCreateAlreadyExists(t *testing.T) {
 	resourceName := "circleci_environment_variable." + envName
 
 	resource.Test(t, resource.TestCase{
-		Providers: testAccOrgProviders,
+		ProtoV6ProviderFactories: testAccOrgProviders,
 		PreCheck: func() {
 			testAccPreCheck(t)
 		},
@@ -146,8 +186,8 @@ func TestAccCircleCIEnvironmentVariableImportProviderOrg(t *testing.T) {
 		PreCheck: func() {
 			testAccPreCheck(t)
 		},
-		Providers:    testAccOrgProviders,
-		CheckDestroy: testAccCircleCIEnvironmentVariableProviderOrgCheckDestroy,
+		ProtoV6ProviderFactories: testAccOrgProviders,
+		CheckDestroy:             testAccCircleCIEnvironmentVariableProviderOrgCheckDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccCircleCIEnvironmentVariableConfigResourceOrg(organization, project, envName, "value-for-the-test"),
@@ -181,8 +221,8 @@ func TestAccCircleCIEnvironmentVariableImportResourceOrg(t *testing.T) {
 		PreCheck: func() {
 			testAccPreCheck(t)
 		},
-		Providers:    testAccNoOrgProviders,
-		CheckDestroy: testAccCircleCIEnvironmentVariableResourceOrgCheckDestroy,
+		ProtoV6ProviderFactories: testAccNoOrgProviders,
+		CheckDestroy:             testAccCircleCIEnvironmentVariableResourceOrgCheckDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccCircleCIEnvironmentVariableConfigResourceOrg(organization, project, envName, "value-for-the-test"),
@@ -226,6 +266,169 @@ func TestParseEnvironmentVariableId(t *testing.T) {
 	}
 }
 
+func TestHashValueOnDiff(t *testing.T) {
+	r := resourceCircleCIEnvironmentVariable()
+	config := map[string]interface{}{
+		"project": "project",
+		"name":    "NAME",
+		"value":   "secret",
+	}
+	rawConfig := testRawConfig(r, map[string]cty.Value{
+		"project": cty.StringVal("project"),
+		"name":    cty.StringVal("NAME"),
+		"value":   cty.StringVal("secret"),
+	})
+
+	c, err := client.New(client.Config{URL: "https://circleci.com/api/v2/", ValueHashKey: "key"})
+	assert.NoError(t, err)
+
+	// The hash is keyed by the client of the provider
+	diff, err := r.SimpleDiff(context.Background(), &terraform.InstanceState{RawConfig: rawConfig}, terraform.NewResourceConfigRaw(config), c)
+	assert.NoError(t, err)
+	assert.Equal(t, c.HashValue("secret"), diff.Attributes["value"].New)
+	assert.NotEqual(t, hashString("secret"), diff.Attributes["value"].New)
+
+	// An unchanged value has no diff
+	state := &terraform.InstanceState{
+		ID: "org.project.NAME",
+		Attributes: map[string]string{
+			"id":           "org.project.NAME",
+			"project":      "project",
+			"name":         "NAME",
+			"value":        c.HashValue("secret"),
+			"masked_value": "xxxxcret",
+			"created_at":   "2024-01-01T00:00:00Z",
+		},
+		RawConfig: rawConfig,
+	}
+
+	diff, err = r.SimpleDiff(context.Background(), state, terraform.NewResourceConfigRaw(config), c)
+	assert.NoError(t, err)
+	assert.Empty(t, diff.Attributes)
+}
+
+func TestEnvironmentVariableReadDrift(t *testing.T) {
+	r := resourceCircleCIEnvironmentVariable()
+	config := map[string]interface{}{
+		"organization": "org",
+		"project":      "project",
+		"name":         "NAME",
+		"value":        "secret",
+	}
+	rawConfig := testRawConfig(r, map[string]cty.Value{
+		"organization": cty.StringVal("org"),
+		"project":      cty.StringVal("project"),
+		"name":         cty.StringVal("NAME"),
+		"value":        cty.StringVal("secret"),
+	})
+
+	cases := []struct {
+		name   string
+		remote string
+		drift  bool
+	}{
+		{"unchanged", `{"name": "NAME", "value": "xxxxcret", "created-at": "2024-01-01T00:00:00Z"}`, false},
+		{"masked value", `{"name": "NAME", "value": "xxxxanged", "created-at": "2024-01-01T00:00:00Z"}`, true},
+		{"creation date", `{"name": "NAME", "value": "xxxxcret", "created-at": "2024-02-01T00:00:00Z"}`, true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/api/v2/project/github/org/project/envvar/NAME", r.URL.Path)
+				_, _ = w.Write([]byte(tc.remote))
+			}))
+			defer server.Close()
+
+			c, err := client.New(client.Config{URL: server.URL + "/api/v2/", Token: "token", VCS: "github"})
+			assert.NoError(t, err)
+
+			d := r.Data(&terraform.InstanceState{
+				ID: "org.project.NAME",
+				Attributes: map[string]string{
+					"id":           "org.project.NAME",
+					"organization": "org",
+					"project":      "project",
+					"name":         "NAME",
+					"value":        c.HashValue("secret"),
+					"masked_value": "xxxxcret",
+					"created_at":   "2024-01-01T00:00:00Z",
+				},
+			})
+			assert.NoError(t, resourceCircleCIEnvironmentVariableRead(d, c))
+
+			// A variable changed outside of Terraform plans an update back to the configured value
+			state := d.State()
+			state.RawConfig = rawConfig
+			diff, err := r.SimpleDiff(context.Background(), state, terraform.NewResourceConfigRaw(config), c)
+			assert.NoError(t, err)
+			if tc.drift {
+				if assert.Contains(t, diff.Attributes, "value") && assert.Contains(t, diff.Attributes, "masked_value") {
+					assert.Equal(t, c.HashValue("secret"), diff.Attributes["value"].New)
+					assert.True(t, diff.Attributes["masked_value"].NewComputed)
+				}
+			} else {
+				assert.Empty(t, diff.Attributes)
+			}
+		})
+	}
+}
+
+func TestEnvironmentVariableValueValidate(t *testing.T) {
+	cases := []struct {
+		name   string
+		config map[string]interface{}
+		errors int
+	}{
+		{"value", map[string]interface{}{"value": "secret"}, 0},
+		{"value_wo with version", map[string]interface{}{"value_wo": "secret", "value_wo_version": 1}, 0},
+		{"value_wo without version", map[string]interface{}{"value_wo": "secret"}, 1},
+		{"version without value_wo", map[string]interface{}{"value": "secret", "value_wo_version": 1}, 1},
+		{"value and value_wo", map[string]interface{}{"value": "secret", "value_wo": "secret", "value_wo_version": 1}, 2},
+	}
+
+	resources := map[string]struct {
+		resource *schema.Resource
+		config   map[string]interface{}
+	}{
+		"circleci_environment_variable":         {resourceCircleCIEnvironmentVariable(), map[string]interface{}{"project": "project", "name": "NAME"}},
+		"circleci_context_environment_variable": {resourceCircleCIContextEnvironmentVariable(), map[string]interface{}{"context_id": "context", "variable": "NAME"}},
+	}
+
+	for name, r := range resources {
+		for _, tc := range cases {
+			t.Run(name+"/"+tc.name, func(t *testing.T) {
+				config := map[string]interface{}{}
+				for k, v := range r.config {
+					config[k] = v
+				}
+				for k, v := range tc.config {
+					config[k] = v
+				}
+
+				diags := r.resource.Validate(terraform.NewResourceConfigRaw(config))
+				if len(diags) != tc.errors {
+					t.Errorf("expected %d errors, got %v", tc.errors, diags)
+				}
+			})
+		}
+	}
+}
+
+// testRawConfig returns the raw configuration of a resource, with null values for the missing attributes
+func testRawConfig(r *schema.Resource, attributes map[string]cty.Value) cty.Value {
+	values := map[string]cty.Value{}
+	for name, ty := range r.CoreConfigSchema().ImpliedType().AttributeTypes() {
+		if value, ok := attributes[name]; ok {
+			values[name] = value
+		} else {
+			values[name] = cty.NullVal(ty)
+		}
+	}
+
+	return cty.ObjectVal(values)
+}
+
 func testCircleCIEnvironmentVariableResourceOrgStateDataV0(organization, project, name string) map[string]interface{} {
 	return map[string]interface{}{
 		"id":           name,
@@ -273,7 +476,7 @@ func TestCircleCIEnvironmentVariableResourceOrgStateUpgradeV0(t *testing.T) {
 		VCS:   os.Getenv("CIRCLECI_VCS_TYPE"),
 	})
 
-	actual, err := resourceCircleCIEnvironmentVariableUpgradeV0(testCircleCIEnvironmentVariableResourceOrgStateDataV0(organization, project, envName), c)
+	actual, err := resourceCircleCIEnvironmentVariableUpgradeV0(context.Background(), testCircleCIEnvironmentVariableResourceOrgStateDataV0(organization, project, envName), c)
 	if err != nil {
 		t.Fatalf("error migrating state: %s", err)
 	}
@@ -294,7 +497,7 @@ func TestCircleCIEnvironmentVariableProviderOrgStateUpgradeV0(t *testing.T) {
 		Organization: os.Getenv("TEST_CIRCLECI_ORGANIZATION"),
 	})
 
-	actual, err := resourceCircleCIEnvironmentVariableUpgradeV0(testCircleCIEnvironmentVariableNoOrgStateDataProviderOrgV0(project, envName), c)
+	actual, err := resourceCircleCIEnvironmentVariableUpgradeV0(context.Background(), testCircleCIEnvironmentVariableNoOrgStateDataProviderOrgV0(project, envName), c)
 	if err != nil {
 		t.Fatalf("error migrating state: %s", err)
 	}
@@ -351,6 +554,16 @@ resource "circleci_environment_variable" "%[2]s" {
 }`, project, name, value)
 }
 
+func testAccCircleCIEnvironmentVariableConfigWriteOnly(project, name, value string, version int) string {
+	return fmt.Sprintf(`
+resource "circleci_environment_variable" "%[2]s" {
+  project          = "%[1]s"
+  name             = "%[2]s"
+  value_wo         = "%[3]s"
+  value_wo_version = %[4]d
+}`, project, name, value, version)
+}
+
 func testAccCircleCIEnvironmentVariableConfigResourceOrg(organization, project, name, value string) string {
 	return fmt.Sprintf(`
 resource "circleci_environment_variable" "%[2]s" {
@@ -0,0 +1,120 @@
+package circleci
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	client "github.com/mrolla/terraform-provider-circleci/circleci/client"
+)
+
+func resourceCircleCIProject() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceCircleCIProjectCreate,
+		Read:   resourceCircleCIProjectRead,
+		Delete: resourceCircleCIProjectDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The name of the project (the repository name)",
+			},
+			"organization": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The organization where the project is defined",
+			},
+			"slug": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The slug of the project, in the form vcs/organization/project",
+			},
+			"project_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID (UUID) of the project",
+			},
+			"organization_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID (UUID) of the organization owning the project",
+			},
+			"vcs_url": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The URL of the repository backing the project",
+			},
+			"default_branch": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The default branch of the repository backing the project",
+			},
+		},
+	}
+}
+
+func resourceCircleCIProjectCreate(d *schema.ResourceData, m interface{}) error {
+	c := m.(*client.Client)
+
+	org := d.Get("organization").(string)
+	name := d.Get("name").(string)
+
+	slug, err := c.Slug(org, name)
+	if err != nil {
+		return err
+	}
+
+	// Projects which are already followed are adopted as they are
+	project, err := c.GetProject(slug)
+	if errors.Is(err, client.ErrProjectNotFound) {
+		project, err = c.CreateProject(org, name)
+	}
+	if err != nil {
+		return fmt.Errorf("error creating project: %w", err)
+	}
+
+	d.SetId(project.Slug)
+	return resourceCircleCIProjectRead(d, m)
+}
+
+func resourceCircleCIProjectRead(d *schema.ResourceData, m interface{}) error {
+	c := m.(*client.Client)
+
+	project, err := c.GetProject(d.Id())
+	if err != nil {
+		if errors.Is(err, client.ErrProjectNotFound) {
+			d.SetId("")
+			return nil
+		}
+
+		return err
+	}
+
+	d.SetId(project.Slug)
+	_ = d.Set("name", project.Name)
+	// The organization can be configured by its name or by its ID, which is kept
+	if d.Get("organization").(string) != project.OrganizationID {
+		_ = d.Set("organization", project.OrganizationName)
+	}
+	_ = d.Set("slug", project.Slug)
+	_ = d.Set("project_id", project.ID)
+	_ = d.Set("organization_id", project.OrganizationID)
+	_ = d.Set("vcs_url", project.VCSInfo.URL)
+	_ = d.Set("default_branch", project.VCSInfo.DefaultBranch)
+
+	return nil
+}
+
+// Projects cannot be unfollowed through the v2 API, so deleting only removes the project from the state.
+func resourceCircleCIProjectDelete(d *schema.ResourceData, m interface{}) error {
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,208 @@
+package circleci
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	client "github.com/mrolla/terraform-provider-circleci/circleci/client"
+)
+
+func resourceCircleCIProjectEnvironmentVariables() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceCircleCIProjectEnvironmentVariablesCreate,
+		Read:   resourceCircleCIProjectEnvironmentVariablesRead,
+		Update: resourceCircleCIProjectEnvironmentVariablesUpdate,
+		Delete: resourceCircleCIProjectEnvironmentVariablesDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceCircleCIProjectEnvironmentVariablesImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"project": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The name, ID or slug of the CircleCI project where the environment variables are defined",
+			},
+			"organization": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The organization where the project is defined",
+			},
+			"variables": {
+				Type:         schema.TypeMap,
+				Optional:     true,
+				Computed:     true,
+				Sensitive:    true,
+				Elem:         &schema.Schema{Type: schema.TypeString},
+				ValidateFunc: validateEnvironmentVariableNamesFunc,
+				Description:  "All environment variables of the project, by name. Hashes of the values are stored in the state.",
+			},
+		},
+
+		CustomizeDiff: hashEnvironmentVariablesOnDiff,
+	}
+}
+
+func resourceCircleCIProjectEnvironmentVariablesCreate(d *schema.ResourceData, m interface{}) error {
+	c := m.(*client.Client)
+
+	organization, err := c.ProjectOrganization(d.Get("organization").(string), d.Get("project").(string))
+	if err != nil {
+		return err
+	}
+
+	project := d.Get("project").(string)
+
+	values, err := configuredEnvironmentVariables(d)
+	if err != nil {
+		return err
+	}
+
+	envs, err := c.ListProjectEnvironmentVariables(organization, project)
+	if err != nil {
+		return fmt.Errorf("failed to get project environment variables: %w", err)
+	}
+
+	names := make([]string, 0, len(*envs))
+	for _, env := range *envs {
+		names = append(names, env.Name)
+	}
+
+	// Creating the resource would delete the variables which are not configured, without showing them in the plan
+	if unmanaged := unmanagedEnvironmentVariables(names, values); len(unmanaged) > 0 {
+		return fmt.Errorf("project %s already has environment variables which are not configured: %s. Add them to variables, or import the existing variables with terraform import and review the plan", project, strings.Join(unmanaged, ", "))
+	}
+
+	// Configured variables which already exist are overwritten
+	old := map[string]interface{}{}
+	for _, name := range names {
+		old[name] = ""
+	}
+
+	d.SetId(projectResourceID(organization, project))
+	_ = d.Set("organization", organization)
+
+	if err := resourceCircleCIProjectEnvironmentVariablesApply(c, d, old, values); err != nil {
+		return err
+	}
+
+	return resourceCircleCIProjectEnvironmentVariablesRead(d, m)
+}
+
+func resourceCircleCIProjectEnvironmentVariablesRead(d *schema.ResourceData, m interface{}) error {
+	c := m.(*client.Client)
+
+	organization, err := c.ProjectOrganization(d.Get("organization").(string), d.Get("project").(string))
+	if err != nil {
+		return err
+	}
+
+	envs, err := c.ListProjectEnvironmentVariables(organization, d.Get("project").(string))
+	if err != nil {
+		if errors.Is(err, client.ErrProjectNotFound) {
+			d.SetId("")
+			return nil
+		}
+
+		return fmt.Errorf("failed to get project environment variables: %w", err)
+	}
+
+	names := make([]string, 0, len(*envs))
+	for _, env := range *envs {
+		names = append(names, env.Name)
+	}
+
+	_ = d.Set("organization", organization)
+	_ = d.Set("variables", refreshEnvironmentVariableHashes(d.Get("variables").(map[string]interface{}), names))
+
+	return nil
+}
+
+func resourceCircleCIProjectEnvironmentVariablesUpdate(d *schema.ResourceData, m interface{}) error {
+	c := m.(*client.Client)
+
+	values, err := configuredEnvironmentVariables(d)
+	if err != nil {
+		return err
+	}
+
+	old, _ := d.GetChange("variables")
+
+	if err := resourceCircleCIProjectEnvironmentVariablesApply(c, d, old.(map[string]interface{}), values); err != nil {
+		return err
+	}
+
+	return resourceCircleCIProjectEnvironmentVariablesRead(d, m)
+}
+
+func resourceCircleCIProjectEnvironmentVariablesDelete(d *schema.ResourceData, m interface{}) error {
+	c := m.(*client.Client)
+
+	organization := d.Get("organization").(string)
+	project := d.Get("project").(string)
+
+	for name := range d.Get("variables").(map[string]interface{}) {
+		if err := c.DeleteProjectEnvironmentVariable(organization, project, name); err != nil {
+			return fmt.Errorf("error deleting environment variable %s: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+func resourceCircleCIProjectEnvironmentVariablesImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	organization, project, err := parseProjectResourceID(d.Id())
+	if err != nil {
+		return nil, fmt.Errorf("importing project environment variables requires $organization/$project or a project slug: %w", err)
+	}
+
+	_ = d.Set("organization", organization)
+	_ = d.Set("project", project)
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// projectResourceID returns the ID of a resource holding a single object of a project, which is
+// $organization/$project, or the project slug when the project is configured by its slug
+func projectResourceID(organization, project string) string {
+	if client.IsProjectSlug(project) {
+		return project
+	}
+
+	return fmt.Sprintf("%s/%s", organization, project)
+}
+
+// parseProjectResourceID returns the organization and project of an ID built by projectResourceID
+func parseProjectResourceID(id string) (string, string, error) {
+	parts := strings.Split(id, "/")
+	switch len(parts) {
+	case 2:
+		return parts[0], parts[1], nil
+	case 3:
+		return parts[1], id, nil
+	}
+
+	return "", "", fmt.Errorf("invalid ID %q", id)
+}
+
+// resourceCircleCIProjectEnvironmentVariablesApply stores the configured variables which differ from the
+// old hashes and deletes the old variables which are not configured anymore.
+func resourceCircleCIProjectEnvironmentVariablesApply(c *client.Client, d *schema.ResourceData, old map[string]interface{}, values map[string]string) error {
+	organization := d.Get("organization").(string)
+	project := d.Get("project").(string)
+
+	return applyEnvironmentVariables(c, d, old, values,
+		func(name, value string) error {
+			return c.CreateProjectEnvironmentVariable(organization, project, name, value)
+		},
+		func(name string) error {
+			return c.DeleteProjectEnvironmentVariable(organization, project, name)
+		},
+	)
+}
@@ -0,0 +1,95 @@
+package circleci
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+
+	client "github.com/mrolla/terraform-provider-circleci/circleci/client"
+)
+
+func TestAccCircleCIProjectEnvironmentVariables_basic(t *testing.T) {
+	project := os.Getenv("CIRCLECI_PROJECT")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccOrgProviders,
+		CheckDestroy:             testAccCheckCircleCIProjectEnvironmentVariablesDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCircleCIProjectEnvironmentVariablesConfig(project, map[string]string{
+					"TOKEN_A": "secret",
+					"TOKEN_B": "secret",
+				}),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("circleci_project_environment_variables.foo", "variables.%", "2"),
+					resource.TestCheckResourceAttr("circleci_project_environment_variables.foo", "variables.TOKEN_A", hashString("secret")),
+					resource.TestCheckResourceAttr("circleci_project_environment_variables.foo", "variables.TOKEN_B", hashString("secret")),
+				),
+			},
+			{
+				Config: testAccCircleCIProjectEnvironmentVariablesConfig(project, map[string]string{
+					"TOKEN_A": "changed",
+					"TOKEN_C": "secret",
+				}),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("circleci_project_environment_variables.foo", "variables.%", "2"),
+					resource.TestCheckResourceAttr("circleci_project_environment_variables.foo", "variables.TOKEN_A", hashString("changed")),
+					resource.TestCheckResourceAttr("circleci_project_environment_variables.foo", "variables.TOKEN_C", hashString("secret")),
+				),
+			},
+		},
+	})
+}
+
+func TestParseProjectResourceID(t *testing.T) {
+	organization, project, err := parseProjectResourceID(projectResourceID("org", "project"))
+	assert.NoError(t, err)
+	assert.Equal(t, "org", organization)
+	assert.Equal(t, "project", project)
+
+	slug := "circleci/8f2ae5c5-4a37-4d0e-a1f6-8c1a3f0e5b51/2b6a2bd4-0cbb-4b0e-9d3e-4fd4c1a3e0c7"
+	organization, project, err = parseProjectResourceID(projectResourceID("", slug))
+	assert.NoError(t, err)
+	assert.Equal(t, "8f2ae5c5-4a37-4d0e-a1f6-8c1a3f0e5b51", organization)
+	assert.Equal(t, slug, project)
+
+	_, _, err = parseProjectResourceID("project")
+	assert.Error(t, err)
+}
+
+func testAccCheckCircleCIProjectEnvironmentVariablesDestroy(s *terraform.State) error {
+	c := testAccOrgProvider.Meta().(*client.Client)
+
+	for _, resource := range s.RootModule().Resources {
+		if resource.Type != "circleci_project_environment_variables" {
+			continue
+		}
+
+		envs, err := c.ListProjectEnvironmentVariables(resource.Primary.Attributes["organization"], resource.Primary.Attributes["project"])
+		if err == nil && len(*envs) > 0 {
+			return fmt.Errorf("Project %s still has environment variables", resource.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+func testAccCircleCIProjectEnvironmentVariablesConfig(project string, variables map[string]string) string {
+	config := fmt.Sprintf(`
+resource "circleci_project_environment_variables" "foo" {
+  project = "%s"
+
+  variables = {
+`, project)
+
+	for name, value := range variables {
+		config += fmt.Sprintf("    %s = %q\n", name, value)
+	}
+
+	return config + "  }\n}\n"
+}
@@ -0,0 +1,235 @@
+package circleci
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	client "github.com/mrolla/terraform-provider-circleci/circleci/client"
+)
+
+func resourceCircleCIProjectSettings() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceCircleCIProjectSettingsCreate,
+		Read:   resourceCircleCIProjectSettingsRead,
+		Update: resourceCircleCIProjectSettingsUpdate,
+		Delete: resourceCircleCIProjectSettingsDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceCircleCIProjectSettingsImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"project": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The name, ID or slug of the CircleCI project to configure",
+			},
+			"organization": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The organization where the project is defined",
+			},
+			"auto_cancel_builds": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+				Description: "Whether redundant workflows on non-default branches are auto-cancelled",
+			},
+			"build_fork_prs": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+				Description: "Whether pull requests from forks are built",
+			},
+			"build_prs_only": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+				Description: "Whether only branches with an open pull request are built",
+			},
+			"disable_ssh": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+				Description: "Whether re-running jobs with SSH is disabled",
+			},
+			"forks_receive_secret_env_vars": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+				Description: "Whether builds of pull requests from forks receive secrets",
+			},
+			"oss": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+				Description: "Whether the project is an open source project",
+			},
+			"set_github_status": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+				Description: "Whether the build status is reported to GitHub",
+			},
+			"setup_workflows": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+				Description: "Whether dynamic configuration using setup workflows is enabled",
+			},
+			"write_settings_requires_admin": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+				Description: "Whether changing the project settings requires admin permissions",
+			},
+			"pr_only_branch_overrides": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The branches that are always built, even when only pull requests are built",
+			},
+		},
+	}
+}
+
+func resourceCircleCIProjectSettingsCreate(d *schema.ResourceData, m interface{}) error {
+	c := m.(*client.Client)
+
+	organization, err := c.ProjectOrganization(d.Get("organization").(string), d.Get("project").(string))
+	if err != nil {
+		return err
+	}
+
+	project := d.Get("project").(string)
+
+	settings := &client.ProjectSettings{
+		Advanced: expandProjectAdvancedSettings(d, false),
+	}
+
+	if _, err := c.UpdateProjectSettings(organization, project, settings); err != nil {
+		return fmt.Errorf("error updating project settings: %w", err)
+	}
+
+	d.SetId(projectResourceID(organization, project))
+	return resourceCircleCIProjectSettingsRead(d, m)
+}
+
+func resourceCircleCIProjectSettingsRead(d *schema.ResourceData, m interface{}) error {
+	c := m.(*client.Client)
+
+	organization, err := c.ProjectOrganization(d.Get("organization").(string), d.Get("project").(string))
+	if err != nil {
+		return err
+	}
+
+	settings, err := c.GetProjectSettings(organization, d.Get("project").(string))
+	if err != nil {
+		if errors.Is(err, client.ErrProjectNotFound) {
+			d.SetId("")
+			return nil
+		}
+
+		return fmt.Errorf("failed to get project settings: %w", err)
+	}
+
+	advanced := settings.Advanced
+
+	_ = d.Set("organization", organization)
+	_ = d.Set("auto_cancel_builds", advanced.AutocancelBuilds)
+	_ = d.Set("build_fork_prs", advanced.BuildForkPRs)
+	_ = d.Set("build_prs_only", advanced.BuildPRsOnly)
+	_ = d.Set("disable_ssh", advanced.DisableSSH)
+	_ = d.Set("forks_receive_secret_env_vars", advanced.ForksReceiveSecretEnvVars)
+	_ = d.Set("oss", advanced.OSS)
+	_ = d.Set("set_github_status", advanced.SetGithubStatus)
+	_ = d.Set("setup_workflows", advanced.SetupWorkflows)
+	_ = d.Set("write_settings_requires_admin", advanced.WriteSettingsRequiresAdmin)
+
+	var overrides []string
+	if advanced.PROnlyBranchOverrides != nil {
+		overrides = *advanced.PROnlyBranchOverrides
+	}
+	_ = d.Set("pr_only_branch_overrides", overrides)
+
+	return nil
+}
+
+func resourceCircleCIProjectSettingsUpdate(d *schema.ResourceData, m interface{}) error {
+	c := m.(*client.Client)
+
+	organization, err := c.ProjectOrganization(d.Get("organization").(string), d.Get("project").(string))
+	if err != nil {
+		return err
+	}
+
+	settings := &client.ProjectSettings{
+		Advanced: expandProjectAdvancedSettings(d, true),
+	}
+
+	if _, err := c.UpdateProjectSettings(organization, d.Get("project").(string), settings); err != nil {
+		return fmt.Errorf("error updating project settings: %w", err)
+	}
+
+	return resourceCircleCIProjectSettingsRead(d, m)
+}
+
+// Project settings cannot be removed, so deleting only removes them from the state.
+func resourceCircleCIProjectSettingsDelete(d *schema.ResourceData, m interface{}) error {
+	d.SetId("")
+	return nil
+}
+
+func resourceCircleCIProjectSettingsImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	organization, project, err := parseProjectResourceID(d.Id())
+	if err != nil {
+		return nil, fmt.Errorf("importing project settings requires $organization/$project or a project slug: %w", err)
+	}
+
+	_ = d.Set("organization", organization)
+	_ = d.Set("project", project)
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// expandProjectAdvancedSettings builds the settings to send from the configuration.
+// When onlyChanged is set, only the settings which changed are included.
+func expandProjectAdvancedSettings(d *schema.ResourceData, onlyChanged bool) client.AdvancedProjectSettings {
+	setting := func(key string) *bool {
+		if onlyChanged {
+			if !d.HasChange(key) {
+				return nil
+			}
+		} else if _, ok := d.GetOkExists(key); !ok {
+			return nil
+		}
+
+		v := d.Get(key).(bool)
+		return &v
+	}
+
+	advanced := client.AdvancedProjectSettings{
+		AutocancelBuilds:           setting("auto_cancel_builds"),
+		BuildForkPRs:               setting("build_fork_prs"),
+		BuildPRsOnly:               setting("build_prs_only"),
+		DisableSSH:                 setting("disable_ssh"),
+		ForksReceiveSecretEnvVars:  setting("forks_receive_secret_env_vars"),
+		OSS:                        setting("oss"),
+		SetGithubStatus:            setting("set_github_status"),
+		SetupWorkflows:             setting("setup_workflows"),
+		WriteSettingsRequiresAdmin: setting("write_settings_requires_admin"),
+	}
+
+	_, ok := d.GetOk("pr_only_branch_overrides")
+	if (onlyChanged && d.HasChange("pr_only_branch_overrides")) || (!onlyChanged && ok) {
+		overrides := expandStringSet(d.Get("pr_only_branch_overrides").(*schema.Set))
+		advanced.PROnlyBranchOverrides = &overrides
+	}
+
+	return advanced
+}
@@ -0,0 +1,92 @@
+package circleci
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	client "github.com/mrolla/terraform-provider-circleci/circleci/client"
+)
+
+func TestAccCircleCIProjectSettings_update(t *testing.T) {
+	project := os.Getenv("CIRCLECI_PROJECT")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccOrgProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCircleCIProjectSettingsConfig(project, true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCircleCIProjectSettingsBuildPRsOnly("circleci_project_settings.foo", true),
+					resource.TestCheckResourceAttr("circleci_project_settings.foo", "project", project),
+					resource.TestCheckResourceAttr("circleci_project_settings.foo", "build_prs_only", "true"),
+					resource.TestCheckResourceAttr("circleci_project_settings.foo", "auto_cancel_builds", "true"),
+					resource.TestCheckResourceAttr("circleci_project_settings.foo", "pr_only_branch_overrides.#", "1"),
+				),
+			},
+			{
+				Config: testAccCircleCIProjectSettingsConfig(project, false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckCircleCIProjectSettingsBuildPRsOnly("circleci_project_settings.foo", false),
+					resource.TestCheckResourceAttr("circleci_project_settings.foo", "build_prs_only", "false"),
+					resource.TestCheckResourceAttr("circleci_project_settings.foo", "auto_cancel_builds", "true"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccCircleCIProjectSettings_import(t *testing.T) {
+	project := os.Getenv("CIRCLECI_PROJECT")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccOrgProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCircleCIProjectSettingsConfig(project, true),
+			},
+			{
+				ResourceName:      "circleci_project_settings.foo",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckCircleCIProjectSettingsBuildPRsOnly(addr string, expected bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		c := testAccOrgProvider.Meta().(*client.Client)
+
+		resource, ok := s.RootModule().Resources[addr]
+		if !ok {
+			return fmt.Errorf("Not found: %s", addr)
+		}
+
+		settings, err := c.GetProjectSettings(resource.Primary.Attributes["organization"], resource.Primary.Attributes["project"])
+		if err != nil {
+			return fmt.Errorf("error getting project settings: %w", err)
+		}
+
+		if settings.Advanced.BuildPRsOnly == nil || *settings.Advanced.BuildPRsOnly != expected {
+			return fmt.Errorf("Unexpected build_prs_only setting: %v", settings.Advanced.BuildPRsOnly)
+		}
+
+		return nil
+	}
+}
+
+func testAccCircleCIProjectSettingsConfig(project string, buildPRsOnly bool) string {
+	return fmt.Sprintf(`
+resource "circleci_project_settings" "foo" {
+  project                  = "%s"
+  auto_cancel_builds       = true
+  build_prs_only           = %t
+  pr_only_branch_overrides = ["main"]
+}`, project, buildPRsOnly)
+}
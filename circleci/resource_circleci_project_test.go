@@ -0,0 +1,58 @@
+package circleci
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccCircleCIProject_basic(t *testing.T) {
+	project := os.Getenv("CIRCLECI_PROJECT")
+	organization := os.Getenv("TEST_CIRCLECI_ORGANIZATION")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccOrgProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCircleCIProjectConfig(project),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("circleci_project.foo", "name", project),
+					resource.TestCheckResourceAttr("circleci_project.foo", "organization", organization),
+					resource.TestCheckResourceAttrSet("circleci_project.foo", "slug"),
+					resource.TestCheckResourceAttrSet("circleci_project.foo", "project_id"),
+					resource.TestCheckResourceAttrSet("circleci_project.foo", "organization_id"),
+					resource.TestCheckResourceAttrSet("circleci_project.foo", "vcs_url"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccCircleCIProject_import(t *testing.T) {
+	project := os.Getenv("CIRCLECI_PROJECT")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccOrgProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCircleCIProjectConfig(project),
+			},
+			{
+				ResourceName:      "circleci_project.foo",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCircleCIProjectConfig(project string) string {
+	return fmt.Sprintf(`
+resource "circleci_project" "foo" {
+  name = "%s"
+}`, project)
+}
@@ -0,0 +1,430 @@
+package circleci
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	client "github.com/mrolla/terraform-provider-circleci/circleci/client"
+)
+
+var (
+	scheduleDaysOfWeek = []string{"MON", "TUE", "WED", "THU", "FRI", "SAT", "SUN"}
+	scheduleMonths     = []string{"JAN", "FEB", "MAR", "APR", "MAY", "JUN", "JUL", "AUG", "SEP", "OCT", "NOV", "DEC"}
+
+	scheduleParameterTypes = []string{"string", "boolean", "integer"}
+)
+
+func resourceCircleCISchedule() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceCircleCIScheduleCreate,
+		Read:   resourceCircleCIScheduleRead,
+		Update: resourceCircleCIScheduleUpdate,
+		Delete: resourceCircleCIScheduleDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"project": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The name, ID or slug of the CircleCI project to schedule pipelines for",
+			},
+			"organization": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The organization where the project is defined",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name of the schedule",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The description of the schedule",
+			},
+			"branch": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{"branch", "tag"},
+				Description:  "The branch to run the pipeline on",
+			},
+			"tag": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{"branch", "tag"},
+				Description:  "The tag to run the pipeline on",
+			},
+			"parameters": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The string pipeline parameters passed to the scheduled pipelines",
+			},
+			"parameter": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "A typed pipeline parameter passed to the scheduled pipelines",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The name of the pipeline parameter",
+						},
+						"type": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "string",
+							Description:  "The type of the pipeline parameter, either string, boolean or integer",
+							ValidateFunc: validation.StringInSlice(scheduleParameterTypes, false),
+						},
+						"value": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The value of the pipeline parameter",
+						},
+					},
+				},
+			},
+			"attribution_actor": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "current",
+				Description:  "The actor the scheduled pipelines are attributed to, either current or system",
+				ValidateFunc: validation.StringInSlice([]string{"current", "system"}, false),
+			},
+			"timetable": {
+				Type:        schema.TypeList,
+				Required:    true,
+				MaxItems:    1,
+				Description: "When the scheduled pipelines are triggered",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"per_hour": {
+							Type:         schema.TypeInt,
+							Required:     true,
+							Description:  "The number of times the pipeline is triggered per hour",
+							ValidateFunc: validation.IntBetween(1, 60),
+						},
+						"hours_of_day": {
+							Type:        schema.TypeSet,
+							Required:    true,
+							Description: "The hours of the day (UTC) the pipeline is triggered",
+							Elem: &schema.Schema{
+								Type:         schema.TypeInt,
+								ValidateFunc: validation.IntBetween(0, 23),
+							},
+						},
+						"days_of_week": {
+							Type:         schema.TypeSet,
+							Optional:     true,
+							AtLeastOneOf: []string{"timetable.0.days_of_week", "timetable.0.days_of_month"},
+							Description:  "The days of the week the pipeline is triggered",
+							Elem: &schema.Schema{
+								Type:         schema.TypeString,
+								ValidateFunc: validation.StringInSlice(scheduleDaysOfWeek, false),
+							},
+						},
+						"days_of_month": {
+							Type:         schema.TypeSet,
+							Optional:     true,
+							AtLeastOneOf: []string{"timetable.0.days_of_week", "timetable.0.days_of_month"},
+							Description:  "The days of the month the pipeline is triggered",
+							Elem: &schema.Schema{
+								Type:         schema.TypeInt,
+								ValidateFunc: validation.IntBetween(1, 31),
+							},
+						},
+						"months": {
+							Type:        schema.TypeSet,
+							Optional:    true,
+							Description: "The months the pipeline is triggered",
+							Elem: &schema.Schema{
+								Type:         schema.TypeString,
+								ValidateFunc: validation.StringInSlice(scheduleMonths, false),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceCircleCIScheduleCreate(d *schema.ResourceData, m interface{}) error {
+	c := m.(*client.Client)
+
+	organization, err := c.ProjectOrganization(d.Get("organization").(string), d.Get("project").(string))
+	if err != nil {
+		return err
+	}
+
+	schedule, err := expandSchedule(d)
+	if err != nil {
+		return err
+	}
+
+	schedule, err = c.CreateSchedule(organization, d.Get("project").(string), schedule)
+	if err != nil {
+		return fmt.Errorf("error creating schedule: %w", err)
+	}
+
+	d.SetId(schedule.ID)
+	return resourceCircleCIScheduleRead(d, m)
+}
+
+// organizationAttribute returns the organization to store in the state. The configured organization is kept
+// when it refers to the organization returned by the API, e.g. when it is configured by its ID while the API
+// returns its name, so that the resource is not replaced.
+func organizationAttribute(c *client.Client, configured, remote string) string {
+	if configured == "" || configured == remote {
+		return remote
+	}
+
+	configuredID, err := c.GetOrganizationID(configured)
+	if err != nil {
+		return remote
+	}
+
+	remoteID, err := c.GetOrganizationID(remote)
+	if err != nil || remoteID != configuredID {
+		return remote
+	}
+
+	return configured
+}
+
+func resourceCircleCIScheduleRead(d *schema.ResourceData, m interface{}) error {
+	c := m.(*client.Client)
+
+	schedule, err := c.GetSchedule(d.Id())
+	if err != nil {
+		if errors.Is(err, client.ErrScheduleNotFound) {
+			d.SetId("")
+			return nil
+		}
+
+		return fmt.Errorf("failed to get schedule: %w", err)
+	}
+
+	// The project slug has the form vcs/organization/project. A project configured by its slug is kept.
+	parts := strings.Split(schedule.ProjectSlug, "/")
+	if len(parts) == 3 {
+		_ = d.Set("organization", organizationAttribute(c, d.Get("organization").(string), parts[1]))
+		if !client.IsProjectSlug(d.Get("project").(string)) {
+			_ = d.Set("project", parts[2])
+		}
+	}
+
+	_ = d.Set("name", schedule.Name)
+	_ = d.Set("description", schedule.Description)
+
+	actor, err := c.ScheduleAttributionActor(schedule)
+	if err != nil {
+		return fmt.Errorf("failed to get schedule attribution actor: %w", err)
+	}
+	if actor != "" {
+		_ = d.Set("attribution_actor", actor)
+	}
+
+	_ = d.Set("branch", stringParameter(schedule.Parameters["branch"]))
+	_ = d.Set("tag", stringParameter(schedule.Parameters["tag"]))
+
+	typed := map[string]bool{}
+	for _, p := range d.Get("parameter").(*schema.Set).List() {
+		typed[p.(map[string]interface{})["name"].(string)] = true
+	}
+
+	parameters, typedParameters := flattenScheduleParameters(schedule.Parameters, typed)
+	_ = d.Set("parameters", parameters)
+	if err := d.Set("parameter", typedParameters); err != nil {
+		return fmt.Errorf("failed to set parameter: %w", err)
+	}
+
+	timetable := map[string]interface{}{
+		"per_hour":      schedule.Timetable.PerHour,
+		"hours_of_day":  schedule.Timetable.HoursOfDay,
+		"days_of_week":  schedule.Timetable.DaysOfWeek,
+		"days_of_month": schedule.Timetable.DaysOfMonth,
+		"months":        schedule.Timetable.Months,
+	}
+	if err := d.Set("timetable", []interface{}{timetable}); err != nil {
+		return fmt.Errorf("failed to set timetable: %w", err)
+	}
+
+	return nil
+}
+
+func resourceCircleCIScheduleUpdate(d *schema.ResourceData, m interface{}) error {
+	c := m.(*client.Client)
+
+	schedule, err := expandSchedule(d)
+	if err != nil {
+		return err
+	}
+
+	if _, err := c.UpdateSchedule(d.Id(), schedule); err != nil {
+		return fmt.Errorf("error updating schedule: %w", err)
+	}
+
+	return resourceCircleCIScheduleRead(d, m)
+}
+
+func resourceCircleCIScheduleDelete(d *schema.ResourceData, m interface{}) error {
+	c := m.(*client.Client)
+
+	if err := c.DeleteSchedule(d.Id()); err != nil {
+		return fmt.Errorf("error deleting schedule: %w", err)
+	}
+
+	return nil
+}
+
+func expandSchedule(d *schema.ResourceData) (*client.Schedule, error) {
+	parameters := map[string]interface{}{}
+	for k, v := range d.Get("parameters").(map[string]interface{}) {
+		parameters[k] = v.(string)
+	}
+
+	for _, p := range d.Get("parameter").(*schema.Set).List() {
+		p := p.(map[string]interface{})
+		name := p["name"].(string)
+
+		if _, ok := parameters[name]; ok {
+			return nil, fmt.Errorf("pipeline parameter %s is set more than once", name)
+		}
+
+		value, err := expandScheduleParameter(p["type"].(string), p["value"].(string))
+		if err != nil {
+			return nil, fmt.Errorf("invalid pipeline parameter %s: %w", name, err)
+		}
+
+		parameters[name] = value
+	}
+
+	for _, key := range []string{"branch", "tag"} {
+		if _, ok := parameters[key]; ok {
+			return nil, fmt.Errorf("pipeline parameter %s must be set with the %s argument", key, key)
+		}
+
+		if value := d.Get(key).(string); value != "" {
+			parameters[key] = value
+		}
+	}
+
+	timetable := d.Get("timetable").([]interface{})[0].(map[string]interface{})
+
+	return &client.Schedule{
+		Name:             d.Get("name").(string),
+		Description:      d.Get("description").(string),
+		AttributionActor: d.Get("attribution_actor").(string),
+		Parameters:       parameters,
+		Timetable: client.Timetable{
+			PerHour:     timetable["per_hour"].(int),
+			HoursOfDay:  expandIntSet(timetable["hours_of_day"].(*schema.Set)),
+			DaysOfWeek:  expandStringSet(timetable["days_of_week"].(*schema.Set)),
+			DaysOfMonth: expandIntSet(timetable["days_of_month"].(*schema.Set)),
+			Months:      expandStringSet(timetable["months"].(*schema.Set)),
+		},
+	}, nil
+}
+
+// expandScheduleParameter converts the value of a typed pipeline parameter to its type
+func expandScheduleParameter(typ, value string) (interface{}, error) {
+	switch typ {
+	case "boolean":
+		switch value {
+		case "true":
+			return true, nil
+		case "false":
+			return false, nil
+		}
+
+		return nil, fmt.Errorf("expected true or false, got %q", value)
+	case "integer":
+		i, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, fmt.Errorf("expected an integer, got %q", value)
+		}
+
+		return i, nil
+	}
+
+	return value, nil
+}
+
+// flattenScheduleParameters splits the pipeline parameters returned by the API, except the branch and tag,
+// into string parameters and typed parameter blocks. Parameters configured as blocks stay blocks, and
+// parameters which are not strings become blocks of their type.
+func flattenScheduleParameters(parameters map[string]interface{}, typed map[string]bool) (map[string]string, []interface{}) {
+	values := map[string]string{}
+	blocks := []interface{}{}
+
+	for name, value := range parameters {
+		if name == "branch" || name == "tag" {
+			continue
+		}
+
+		typ := "string"
+		switch value.(type) {
+		case bool:
+			typ = "boolean"
+		case float64, int:
+			typ = "integer"
+		}
+
+		if typ == "string" && !typed[name] {
+			values[name] = stringParameter(value)
+			continue
+		}
+
+		blocks = append(blocks, map[string]interface{}{
+			"name":  name,
+			"type":  typ,
+			"value": stringParameter(value),
+		})
+	}
+
+	return values, blocks
+}
+
+// stringParameter returns the value of a pipeline parameter as a string, or an empty string when it is not set
+func stringParameter(value interface{}) string {
+	switch value := value.(type) {
+	case nil:
+		return ""
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	}
+
+	return fmt.Sprint(value)
+}
+
+func expandIntSet(set *schema.Set) []int {
+	values := make([]int, 0, set.Len())
+	for _, v := range set.List() {
+		values = append(values, v.(int))
+	}
+
+	return values
+}
+
+func expandStringSet(set *schema.Set) []string {
+	values := make([]string, 0, set.Len())
+	for _, v := range set.List() {
+		values = append(values, v.(string))
+	}
+
+	return values
+}
@@ -0,0 +1,165 @@
+package circleci
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+
+	client "github.com/mrolla/terraform-provider-circleci/circleci/client"
+)
+
+func TestAccCircleCISchedule_update(t *testing.T) {
+	project := os.Getenv("CIRCLECI_PROJECT")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccOrgProviders,
+		CheckDestroy:             testAccCheckCircleCIScheduleDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCircleCIScheduleConfig(project, "nightly", 2),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("circleci_schedule.foo", "name", "nightly"),
+					resource.TestCheckResourceAttr("circleci_schedule.foo", "project", project),
+					resource.TestCheckResourceAttr("circleci_schedule.foo", "branch", "main"),
+					resource.TestCheckResourceAttr("circleci_schedule.foo", "parameters.version", "007"),
+					resource.TestCheckTypeSetElemNestedAttrs("circleci_schedule.foo", "parameter.*", map[string]string{"name": "deploy", "type": "boolean", "value": "false"}),
+					resource.TestCheckResourceAttr("circleci_schedule.foo", "timetable.0.per_hour", "1"),
+					resource.TestCheckResourceAttr("circleci_schedule.foo", "timetable.0.hours_of_day.#", "1"),
+					resource.TestCheckResourceAttr("circleci_schedule.foo", "timetable.0.days_of_week.#", "2"),
+				),
+			},
+			{
+				Config: testAccCircleCIScheduleConfig(project, "nightly-updated", 3),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("circleci_schedule.foo", "name", "nightly-updated"),
+					resource.TestCheckResourceAttr("circleci_schedule.foo", "timetable.0.hours_of_day.#", "1"),
+				),
+			},
+			{
+				ResourceName:      "circleci_schedule.foo",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestOrganizationAttribute(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`[{"id": "8f2ae5c5-4a37-4d0e-a1f6-8c1a3f0e5b51", "vcs-type": "github", "name": "org", "slug": "gh/org"}]`))
+	}))
+	defer server.Close()
+
+	c, err := client.New(client.Config{URL: server.URL + "/api/v2/", Token: "token", VCS: "github"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// An organization configured by its ID is kept, so that the resource is not replaced
+	assert.Equal(t, "8f2ae5c5-4a37-4d0e-a1f6-8c1a3f0e5b51", organizationAttribute(c, "8f2ae5c5-4a37-4d0e-a1f6-8c1a3f0e5b51", "org"))
+	assert.Equal(t, "org", organizationAttribute(c, "org", "org"))
+	assert.Equal(t, "org", organizationAttribute(c, "", "org"))
+	assert.Equal(t, "org", organizationAttribute(c, "2b6a2bd4-0cbb-4b0e-9d3e-4fd4c1a3e0c7", "org"))
+}
+
+func TestExpandScheduleParameter(t *testing.T) {
+	cases := []struct {
+		typ   string
+		value string
+		want  interface{}
+		err   bool
+	}{
+		{"string", "123", "123", false},
+		{"string", "007", "007", false},
+		{"string", "true", "true", false},
+		{"boolean", "true", true, false},
+		{"boolean", "false", false, false},
+		{"boolean", "True", nil, true},
+		{"integer", "42", 42, false},
+		{"integer", "4.2", nil, true},
+	}
+
+	for _, tc := range cases {
+		value, err := expandScheduleParameter(tc.typ, tc.value)
+		if tc.err {
+			assert.Error(t, err, tc.value)
+			continue
+		}
+
+		if assert.NoError(t, err, tc.value) {
+			assert.Equal(t, tc.want, value, tc.value)
+		}
+	}
+}
+
+func TestFlattenScheduleParameters(t *testing.T) {
+	parameters, blocks := flattenScheduleParameters(map[string]interface{}{
+		"branch":  "main",
+		"version": "007",
+		"deploy":  true,
+		"shards":  float64(4),
+		"region":  "eu",
+	}, map[string]bool{"region": true})
+
+	// Strings are kept as they are, and other types and configured blocks become blocks
+	assert.Equal(t, map[string]string{"version": "007"}, parameters)
+	assert.ElementsMatch(t, []interface{}{
+		map[string]interface{}{"name": "deploy", "type": "boolean", "value": "true"},
+		map[string]interface{}{"name": "shards", "type": "integer", "value": "4"},
+		map[string]interface{}{"name": "region", "type": "string", "value": "eu"},
+	}, blocks)
+}
+
+func testAccCheckCircleCIScheduleDestroy(s *terraform.State) error {
+	c := testAccOrgProvider.Meta().(*client.Client)
+
+	for _, resource := range s.RootModule().Resources {
+		if resource.Type != "circleci_schedule" {
+			continue
+		}
+
+		if resource.Primary.ID == "" {
+			return fmt.Errorf("No instance ID is set")
+		}
+
+		_, err := c.GetSchedule(resource.Primary.ID)
+		if err == nil {
+			return fmt.Errorf("Schedule %s still exists", resource.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+func testAccCircleCIScheduleConfig(project, name string, hour int) string {
+	return fmt.Sprintf(`
+resource "circleci_schedule" "foo" {
+  project     = "%s"
+  name        = "%s"
+  description = "Created by Terraform"
+  branch      = "main"
+
+  parameters = {
+    version = "007"
+  }
+
+  parameter {
+    name  = "deploy"
+    type  = "boolean"
+    value = "false"
+  }
+
+  timetable {
+    per_hour     = 1
+    hours_of_day = [%d]
+    days_of_week = ["MON", "THU"]
+  }
+}`, project, name, hour)
+}
@@ -0,0 +1,121 @@
+package circleci
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"golang.org/x/crypto/ssh"
+
+	client "github.com/mrolla/terraform-provider-circleci/circleci/client"
+)
+
+func resourceCircleCISSHKey() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceCircleCISSHKeyCreate,
+		Read:   resourceCircleCISSHKeyRead,
+		Delete: resourceCircleCISSHKeyDelete,
+
+		Schema: map[string]*schema.Schema{
+			"project": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The name, ID or slug of the CircleCI project to add the SSH key to",
+			},
+			"organization": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "The organization where the project is defined",
+			},
+			"hostname": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The hostname the SSH key is used for",
+			},
+			"private_key": {
+				Type:      schema.TypeString,
+				Required:  true,
+				ForceNew:  true,
+				Sensitive: true,
+				StateFunc: func(value interface{}) string {
+					return hashString(value.(string))
+				},
+				Description: "The private SSH key. A hash of the key is stored in the state.",
+			},
+			"fingerprint": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The MD5 fingerprint of the SSH key",
+			},
+		},
+	}
+}
+
+func resourceCircleCISSHKeyCreate(d *schema.ResourceData, m interface{}) error {
+	c := m.(*client.Client)
+
+	organization, err := c.ProjectOrganization(d.Get("organization").(string), d.Get("project").(string))
+	if err != nil {
+		return err
+	}
+
+	project := d.Get("project").(string)
+	hostname := d.Get("hostname").(string)
+	privateKey := d.Get("private_key").(string)
+
+	// The API does not return the created key, so its fingerprint is computed here
+	signer, err := ssh.ParsePrivateKey([]byte(privateKey))
+	if err != nil {
+		return fmt.Errorf("failed to parse private key: %w", err)
+	}
+	fingerprint := ssh.FingerprintLegacyMD5(signer.PublicKey())
+
+	if err := c.CreateSSHKey(organization, project, hostname, privateKey); err != nil {
+		return fmt.Errorf("failed to create SSH key: %w", err)
+	}
+
+	d.SetId(fingerprint)
+	_ = d.Set("fingerprint", fingerprint)
+
+	return resourceCircleCISSHKeyRead(d, m)
+}
+
+func resourceCircleCISSHKeyRead(d *schema.ResourceData, m interface{}) error {
+	c := m.(*client.Client)
+
+	organization, err := c.ProjectOrganization(d.Get("organization").(string), d.Get("project").(string))
+	if err != nil {
+		return err
+	}
+
+	has, err := c.HasSSHKey(organization, d.Get("project").(string), d.Get("hostname").(string), d.Id())
+	if err != nil {
+		return fmt.Errorf("failed to get SSH keys: %w", err)
+	}
+
+	if !has {
+		d.SetId("")
+		return nil
+	}
+
+	_ = d.Set("fingerprint", d.Id())
+
+	return nil
+}
+
+func resourceCircleCISSHKeyDelete(d *schema.ResourceData, m interface{}) error {
+	c := m.(*client.Client)
+
+	organization, err := c.ProjectOrganization(d.Get("organization").(string), d.Get("project").(string))
+	if err != nil {
+		return err
+	}
+
+	if err := c.DeleteSSHKey(organization, d.Get("project").(string), d.Get("hostname").(string), d.Id()); err != nil {
+		return fmt.Errorf("failed to delete SSH key: %w", err)
+	}
+
+	return nil
+}
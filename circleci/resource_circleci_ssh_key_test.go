@@ -0,0 +1,86 @@
+package circleci
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	client "github.com/mrolla/terraform-provider-circleci/circleci/client"
+)
+
+func TestAccCircleCISSHKey_basic(t *testing.T) {
+	project := os.Getenv("CIRCLECI_PROJECT")
+	privateKey := testAccCircleCISSHKeyGenerate(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccOrgProviders,
+		CheckDestroy:             testAccCheckCircleCISSHKeyDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCircleCISSHKeyConfig(project, privateKey),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("circleci_ssh_key.foo", "project", project),
+					resource.TestCheckResourceAttr("circleci_ssh_key.foo", "hostname", "example.com"),
+					resource.TestCheckResourceAttr("circleci_ssh_key.foo", "private_key", hashString(privateKey)),
+					resource.TestCheckResourceAttrSet("circleci_ssh_key.foo", "fingerprint"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckCircleCISSHKeyDestroy(s *terraform.State) error {
+	c := testAccOrgProvider.Meta().(*client.Client)
+
+	for _, resource := range s.RootModule().Resources {
+		if resource.Type != "circleci_ssh_key" {
+			continue
+		}
+
+		has, err := c.HasSSHKey(
+			os.Getenv("TEST_CIRCLECI_ORGANIZATION"),
+			resource.Primary.Attributes["project"],
+			resource.Primary.Attributes["hostname"],
+			resource.Primary.ID,
+		)
+		if err != nil {
+			return err
+		}
+
+		if has {
+			return fmt.Errorf("SSH key %s still exists", resource.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+func testAccCircleCISSHKeyGenerate(t *testing.T) string {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("error generating SSH key: %s", err)
+	}
+
+	return string(pem.EncodeToMemory(&pem.Block{
+		Type:  "RSA PRIVATE KEY",
+		Bytes: x509.MarshalPKCS1PrivateKey(key),
+	}))
+}
+
+func testAccCircleCISSHKeyConfig(project, privateKey string) string {
+	return fmt.Sprintf(`
+resource "circleci_ssh_key" "foo" {
+  project     = "%s"
+  hostname    = "example.com"
+  private_key = <<EOT
+%sEOT
+}`, project, privateKey)
+}
@@ -0,0 +1,165 @@
+package circleci
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	client "github.com/mrolla/terraform-provider-circleci/circleci/client"
+)
+
+var webhookEvents = []string{"workflow-completed", "job-completed"}
+
+func resourceCircleCIWebhook() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceCircleCIWebhookCreate,
+		Read:   resourceCircleCIWebhookRead,
+		Update: resourceCircleCIWebhookUpdate,
+		Delete: resourceCircleCIWebhookDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"project_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The ID (UUID) of the project the webhook receives events for",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name of the webhook",
+			},
+			"url": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The URL the events are delivered to",
+			},
+			"events": {
+				Type:        schema.TypeSet,
+				Required:    true,
+				Description: "The events the webhook is notified about",
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringInSlice(webhookEvents, false),
+				},
+			},
+			"verify_tls": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether the TLS certificate of the URL is verified",
+			},
+			"signing_secret": {
+				Type:      schema.TypeString,
+				Required:  true,
+				Sensitive: true,
+				StateFunc: func(value interface{}) string {
+					return hashString(value.(string))
+				},
+				Description: "The secret used to sign the delivered events. A hash of the secret is stored in the state.",
+			},
+			"created_at": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The date and time the webhook was created",
+			},
+			"updated_at": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The date and time the webhook was last updated",
+			},
+		},
+	}
+}
+
+func resourceCircleCIWebhookCreate(d *schema.ResourceData, m interface{}) error {
+	c := m.(*client.Client)
+
+	webhook, err := c.CreateWebhook(&client.Webhook{
+		Name:          d.Get("name").(string),
+		URL:           d.Get("url").(string),
+		Events:        expandStringSet(d.Get("events").(*schema.Set)),
+		VerifyTLS:     d.Get("verify_tls").(bool),
+		SigningSecret: d.Get("signing_secret").(string),
+		Scope: &client.WebhookScope{
+			ID:   d.Get("project_id").(string),
+			Type: "project",
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("error creating webhook: %w", err)
+	}
+
+	d.SetId(webhook.ID)
+	return resourceCircleCIWebhookRead(d, m)
+}
+
+func resourceCircleCIWebhookRead(d *schema.ResourceData, m interface{}) error {
+	c := m.(*client.Client)
+
+	webhook, err := c.GetWebhook(d.Id())
+	if err != nil {
+		if errors.Is(err, client.ErrWebhookNotFound) {
+			d.SetId("")
+			return nil
+		}
+
+		return fmt.Errorf("failed to get webhook: %w", err)
+	}
+
+	_ = d.Set("name", webhook.Name)
+	_ = d.Set("url", webhook.URL)
+	_ = d.Set("events", webhook.Events)
+	_ = d.Set("verify_tls", webhook.VerifyTLS)
+
+	if webhook.Scope != nil {
+		_ = d.Set("project_id", webhook.Scope.ID)
+	}
+
+	if webhook.CreatedAt != nil {
+		_ = d.Set("created_at", webhook.CreatedAt.Format(time.RFC3339))
+	}
+
+	if webhook.UpdatedAt != nil {
+		_ = d.Set("updated_at", webhook.UpdatedAt.Format(time.RFC3339))
+	}
+
+	return nil
+}
+
+func resourceCircleCIWebhookUpdate(d *schema.ResourceData, m interface{}) error {
+	c := m.(*client.Client)
+
+	webhook := &client.Webhook{
+		Name:      d.Get("name").(string),
+		URL:       d.Get("url").(string),
+		Events:    expandStringSet(d.Get("events").(*schema.Set)),
+		VerifyTLS: d.Get("verify_tls").(bool),
+	}
+
+	if d.HasChange("signing_secret") {
+		webhook.SigningSecret = d.Get("signing_secret").(string)
+	}
+
+	if _, err := c.UpdateWebhook(d.Id(), webhook); err != nil {
+		return fmt.Errorf("error updating webhook: %w", err)
+	}
+
+	return resourceCircleCIWebhookRead(d, m)
+}
+
+func resourceCircleCIWebhookDelete(d *schema.ResourceData, m interface{}) error {
+	c := m.(*client.Client)
+
+	if err := c.DeleteWebhook(d.Id()); err != nil {
+		return fmt.Errorf("error deleting webhook: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,107 @@
+package circleci
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	client "github.com/mrolla/terraform-provider-circleci/circleci/client"
+)
+
+func TestAccCircleCIWebhook_update(t *testing.T) {
+	project := os.Getenv("CIRCLECI_PROJECT")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccOrgProviders,
+		CheckDestroy:             testAccCheckCircleCIWebhookDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCircleCIWebhookConfig(project, "https://example.com/hook", `["workflow-completed"]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("circleci_webhook.foo", "name", "terraform-test"),
+					resource.TestCheckResourceAttr("circleci_webhook.foo", "url", "https://example.com/hook"),
+					resource.TestCheckResourceAttr("circleci_webhook.foo", "events.#", "1"),
+					resource.TestCheckResourceAttr("circleci_webhook.foo", "verify_tls", "true"),
+					resource.TestCheckResourceAttr("circleci_webhook.foo", "signing_secret", hashString("secret-value")),
+					resource.TestCheckResourceAttrPair("circleci_webhook.foo", "project_id", "circleci_project.foo", "project_id"),
+				),
+			},
+			{
+				Config: testAccCircleCIWebhookConfig(project, "https://example.com/hook-updated", `["workflow-completed", "job-completed"]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("circleci_webhook.foo", "url", "https://example.com/hook-updated"),
+					resource.TestCheckResourceAttr("circleci_webhook.foo", "events.#", "2"),
+				),
+			},
+			{
+				ResourceName:            "circleci_webhook.foo",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"signing_secret"},
+			},
+		},
+	})
+}
+
+func TestAccCircleCIWebhooksDataSource(t *testing.T) {
+	project := os.Getenv("CIRCLECI_PROJECT")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccOrgProviders,
+		CheckDestroy:             testAccCheckCircleCIWebhookDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCircleCIWebhookConfig(project, "https://example.com/hook", `["workflow-completed"]`) + `
+data "circleci_webhooks" "foo" {
+  project_id = circleci_webhook.foo.project_id
+}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.circleci_webhooks.foo", "webhooks.#", "1"),
+					resource.TestCheckResourceAttrPair("data.circleci_webhooks.foo", "webhooks.0.id", "circleci_webhook.foo", "id"),
+					resource.TestCheckResourceAttr("data.circleci_webhooks.foo", "webhooks.0.url", "https://example.com/hook"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckCircleCIWebhookDestroy(s *terraform.State) error {
+	c := testAccOrgProvider.Meta().(*client.Client)
+
+	for _, resource := range s.RootModule().Resources {
+		if resource.Type != "circleci_webhook" {
+			continue
+		}
+
+		if resource.Primary.ID == "" {
+			return fmt.Errorf("No instance ID is set")
+		}
+
+		_, err := c.GetWebhook(resource.Primary.ID)
+		if err == nil {
+			return fmt.Errorf("Webhook %s still exists", resource.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+func testAccCircleCIWebhookConfig(project, url, events string) string {
+	return fmt.Sprintf(`
+resource "circleci_project" "foo" {
+  name = "%s"
+}
+
+resource "circleci_webhook" "foo" {
+  project_id     = circleci_project.foo.project_id
+  name           = "terraform-test"
+  url            = "%s"
+  events         = %s
+  signing_secret = "secret-value"
+}`, project, url, events)
+}
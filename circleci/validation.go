@@ -28,3 +28,33 @@ func validateEnvironmentVariableNameFunc(v interface{}, key string) (warns []str
 
 	return warns, errs
 }
+
+func validateRegexpFunc(v interface{}, key string) (warns []string, errs []error) {
+	value, ok := v.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected type of %s to be string", key)}
+	}
+
+	if _, err := regexp.Compile(value); err != nil {
+		return nil, []error{fmt.Errorf("%s is not a valid regular expression: %w", key, err)}
+	}
+
+	return nil, nil
+}
+
+func validateEnvironmentVariableNamesFunc(v interface{}, key string) (warns []string, errs []error) {
+	variables, ok := v.(map[string]interface{})
+	if !ok {
+		return nil, []error{fmt.Errorf("expected type of %s to be map", key)}
+	}
+
+	for name := range variables {
+		w, e := validateEnvironmentVariableNameFunc(name, fmt.Sprintf("%s.%s", key, name))
+		warns = append(warns, w...)
+		for _, err := range e {
+			errs = append(errs, fmt.Errorf("%s: %w", name, err))
+		}
+	}
+
+	return warns, errs
+}
@@ -46,3 +46,52 @@ func TestValidateEnvironmentVariableName(t *testing.T) {
 		}
 	}
 }
+
+func TestValidateRegexp(t *testing.T) {
+	cases := []struct {
+		Value string
+		Error bool
+	}{
+		{
+			Value: "^prod-",
+		},
+		{
+			Value: "[a-z]+",
+		},
+		{
+			Value: "prod-(",
+			Error: true,
+		},
+	}
+
+	for _, tc := range cases {
+		var value interface{} = tc.Value
+		_, errors := validateRegexpFunc(value, "name_regex")
+
+		if tc.Error != (len(errors) != 0) {
+			if tc.Error {
+				t.Fatalf("expected error, got none (%s)", tc.Value)
+			} else {
+				t.Fatalf("unexpected error(s): %s (%s)", errors, tc.Value)
+			}
+		}
+	}
+}
+
+func TestValidateEnvironmentVariableNames(t *testing.T) {
+	_, errors := validateEnvironmentVariableNamesFunc(map[string]interface{}{
+		"VALID":   "value",
+		"VALID_2": "value",
+	}, "variables")
+	if len(errors) != 0 {
+		t.Fatalf("unexpected error(s): %s", errors)
+	}
+
+	_, errors = validateEnvironmentVariableNamesFunc(map[string]interface{}{
+		"VALID":          "value",
+		"invalid-dashed": "value",
+	}, "variables")
+	if len(errors) == 0 {
+		t.Fatal("expected error, got none")
+	}
+}